@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+
+	"codeberg.org/snonux/tasksamurai/internal/importer"
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// runImport implements "tasksamurai import [flags] FILE": it previews the
+// parsed tasks and creates the ones that are not duplicates of pending
+// tasks. It returns the process exit code.
+func runImport(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	format := fs.String("format", "", "input format: todotxt, csv or markdown (default: from the file extension)")
+	dryRun := fs.Bool("dry-run", false, "only show the preview, do not create tasks")
+	allowDups := fs.Bool("allow-duplicates", false, "also import tasks whose description matches a pending task")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: tasksamurai import [flags] FILE")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	var f importer.Format
+	if *format != "" {
+		var err error
+		if f, err = importer.ParseFormat(*format); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 2
+		}
+	}
+	tasks, err := importer.Load(fs.Arg(0), f)
+	if err != nil {
+		fmt.Fprintln(stderr, "failed to read import file:", err)
+		return 1
+	}
+	if len(tasks) == 0 {
+		fmt.Fprintln(stdout, "No tasks found")
+		return 0
+	}
+
+	ctx := context.Background()
+	existing, err := task.Export(ctx, "status:pending")
+	if err != nil {
+		fmt.Fprintln(stderr, "failed to load tasks:", err)
+		return 1
+	}
+	dups := importer.Duplicates(tasks, existing)
+	if err := importer.WritePreview(stdout, tasks, dups); err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	if *dryRun {
+		return 0
+	}
+
+	selected := tasks
+	if !*allowDups {
+		selected = importer.WithoutDuplicates(tasks, dups)
+	}
+	skipped := len(tasks) - len(selected)
+	if len(selected) == 0 {
+		fmt.Fprintf(stdout, "Nothing to import (%d duplicates skipped)\n", skipped)
+		return 0
+	}
+	if err := task.ImportContext(ctx, selected); err != nil {
+		fmt.Fprintln(stderr, "import failed:", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Imported %d tasks (%d duplicates skipped)\n", len(selected), skipped)
+	return 0
+}
@@ -7,6 +7,7 @@ import (
 
 	"runtime"
 
+	"codeberg.org/snonux/tasksamurai/internal/config"
 	"codeberg.org/snonux/tasksamurai/internal/debug"
 	"codeberg.org/snonux/tasksamurai/internal/task"
 	"codeberg.org/snonux/tasksamurai/internal/ui"
@@ -15,12 +16,22 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "import":
+			os.Exit(runImport(os.Args[2:], os.Stdout, os.Stderr))
+		case "standup":
+			os.Exit(runStandup(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
 	// Set default browser command depending on OS.
 	browserCmdDefault := "firefox"
 	if runtime.GOOS == "darwin" {
 		browserCmdDefault = "open"
 	}
 
+	configPath := flag.String("config", "", "path to the JSON config file (default: <user config dir>/tasksamurai/config.json)")
 	debugLog := flag.String("debug-log", "", "path to debug log file")
 	debugDir := flag.String("debug-dir", "", "directory for runtime debug output (goroutine dumps, profiles)")
 	browserCmd := flag.String("browser-cmd", browserCmdDefault, "command used to open URLs")
@@ -32,6 +43,9 @@ func main() {
 	agentHotkey := flag.String("agent-hotkey", "3", "key used to toggle the +agent/-agent filter")
 	disco := flag.Bool("disco", false, "enable disco mode")
 	ultra := flag.Bool("ultra", false, "start directly in ultra mode")
+	autoRefresh := flag.Bool("auto-refresh", false, "start with auto-refresh enabled")
+	autoRefreshInterval := flag.Duration("auto-refresh-interval", 0, "delay between automatic reloads (e.g. 30s; default 10s)")
+	autoRefreshAdaptive := flag.Bool("auto-refresh-adaptive", false, "back off auto-refresh while tasks are unchanged and speed up after changes")
 	flag.Parse()
 
 	if err := task.SetDebugLog(*debugLog); err != nil {
@@ -43,6 +57,12 @@ func main() {
 	debug.SetDebugDir(*debugDir)
 	debug.InitSignalHandlers()
 
+	cfg, err := loadConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to load config:", err)
+		os.Exit(1)
+	}
+
 	m, err := ui.New(flag.Args(), *browserCmd)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "failed to load tasks:", err)
@@ -56,6 +76,29 @@ func main() {
 	m.SetYouTubeBrowserCmd(*youtubeBrowserCmd)
 	m.SetDisco(*disco)
 	m.SetUltra(*ultra)
+	m.SetTemplates(cfg.Templates)
+	m.SetBoard(cfg.Board)
+	if err := m.SetRules(cfg.RewriteRules()); err != nil {
+		fmt.Fprintln(os.Stderr, "invalid rewrite rule:", err)
+		os.Exit(1)
+	}
+	if err := m.SetEnrichRules(cfg.Enrich); err != nil {
+		fmt.Fprintln(os.Stderr, "invalid enrich rule:", err)
+		os.Exit(1)
+	}
+	m.SetQuickAdd(cfg.QuickAdd)
+	if err := m.SetInboxFilter(cfg.Inbox); err != nil {
+		fmt.Fprintln(os.Stderr, "invalid inbox filter:", err)
+		os.Exit(1)
+	}
+	if *autoRefreshInterval != 0 {
+		if err := m.SetAutoRefreshInterval(*autoRefreshInterval); err != nil {
+			fmt.Fprintln(os.Stderr, "invalid --auto-refresh-interval:", err)
+			os.Exit(1)
+		}
+	}
+	m.SetAutoRefreshAdaptive(*autoRefreshAdaptive)
+	m.SetAutoRefresh(*autoRefresh)
 
 	// Clear the screen before starting the TUI to avoid leaving any
 	// previous command line artefacts behind.
@@ -67,3 +110,19 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// loadConfig reads the config file at path, or at the default location when
+// path is empty. Only an explicitly given path has to exist.
+func loadConfig(path string) (config.Config, error) {
+	if path != "" {
+		if _, err := os.Stat(path); err != nil {
+			return config.Config{}, err
+		}
+		return config.Load(path)
+	}
+	path, err := config.DefaultPath()
+	if err != nil {
+		return config.Config{}, nil
+	}
+	return config.Load(path)
+}
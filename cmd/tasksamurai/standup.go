@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"time"
+
+	"codeberg.org/snonux/tasksamurai/internal/report"
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// runStandup implements "tasksamurai standup [--since DATE]": it prints the
+// standup report as Markdown. It returns the process exit code.
+func runStandup(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("standup", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	since := fs.String("since", "yesterday", "start of the period: yesterday, today, 3d, monday or YYYY-MM-DD")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() != 0 {
+		fmt.Fprintln(stderr, "usage: tasksamurai standup [--since DATE]")
+		return 2
+	}
+
+	from, err := report.ParseSince(*since, time.Now())
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 2
+	}
+	standup, err := report.LoadStandup(context.Background(), task.NewTaskwarrior(), from)
+	if err != nil {
+		fmt.Fprintln(stderr, "failed to load tasks:", err)
+		return 1
+	}
+	fmt.Fprint(stdout, standup.Markdown())
+	return 0
+}
@@ -19,6 +19,7 @@ type Model struct {
 
 	cols        []Column
 	rows        []Row
+	headerRows  map[int]bool
 	cursor      int
 	colCursor   int
 	focus       bool
@@ -122,6 +123,7 @@ type Styles struct {
 	Cell      lipgloss.Style
 	Selected  lipgloss.Style
 	Highlight lipgloss.Style
+	Group     lipgloss.Style // header rows, see SetHeaderRows
 }
 
 // DefaultStyles returns a set of default style definitions for this table.
@@ -131,6 +133,7 @@ func DefaultStyles() Styles {
 		Header:    lipgloss.NewStyle().Bold(true).Padding(0, 1),
 		Cell:      lipgloss.NewStyle().Padding(0, 1),
 		Highlight: lipgloss.NewStyle().Background(lipgloss.Color("57")).Foreground(lipgloss.Color("0")),
+		Group:     lipgloss.NewStyle().Bold(true).Padding(0, 1),
 	}
 }
 
@@ -344,6 +347,38 @@ func (m *Model) SetRows(r []Row) {
 	m.UpdateViewport()
 }
 
+// SetHeaderRows marks the rows at the given indexes as header rows, such as
+// group headings. A header row spans the whole width with its first cell and
+// cannot be selected: the cursor skips it. The marks are kept until the next
+// call, so they must be renewed whenever the rows are rebuilt.
+func (m *Model) SetHeaderRows(rows []int) {
+	m.headerRows = make(map[int]bool, len(rows))
+	for _, r := range rows {
+		m.headerRows[r] = true
+	}
+	m.cursor = m.selectable(clamp(m.cursor, 0, len(m.rows)-1), 1)
+	m.UpdateViewport()
+}
+
+// IsHeaderRow reports whether row r is a header row.
+func (m Model) IsHeaderRow(r int) bool {
+	return m.headerRows[r]
+}
+
+// selectable returns the first row from r in direction dir (1 or -1) that
+// is not a header row, trying the other direction when there is none. When
+// every row is a header row, r is returned.
+func (m Model) selectable(r, dir int) int {
+	for _, d := range []int{dir, -dir} {
+		for i := r; i >= 0 && i < len(m.rows); i += d {
+			if !m.headerRows[i] {
+				return i
+			}
+		}
+	}
+	return r
+}
+
 // SetColumns sets a new columns state.
 func (m *Model) SetColumns(c []Column) {
 	m.cols = c
@@ -384,7 +419,7 @@ func (m Model) ColumnCursor() int {
 
 // SetCursor sets the cursor position in the table.
 func (m *Model) SetCursor(n int) {
-	m.cursor = clamp(n, 0, len(m.rows)-1)
+	m.cursor = m.selectable(clamp(n, 0, len(m.rows)-1), 1)
 	m.UpdateViewport()
 }
 
@@ -395,9 +430,11 @@ func (m *Model) SetColumnCursor(n int) {
 }
 
 // MoveUp moves the selection up by any number of rows.
-// It can not go above the first row.
+// It can not go above the first row. Header rows are skipped.
 func (m *Model) MoveUp(n int) {
-	m.cursor = clamp(m.cursor-n, 0, len(m.rows)-1)
+	prev := m.cursor
+	m.cursor = m.selectable(clamp(m.cursor-n, 0, len(m.rows)-1), -1)
+	n = max(prev-m.cursor, 0)
 	yOffset := m.viewport.YOffset()
 	height := m.viewport.Height()
 	switch {
@@ -412,9 +449,11 @@ func (m *Model) MoveUp(n int) {
 }
 
 // MoveDown moves the selection down by any number of rows.
-// It can not go below the last row.
+// It can not go below the last row. Header rows are skipped.
 func (m *Model) MoveDown(n int) {
-	m.cursor = clamp(m.cursor+n, 0, len(m.rows)-1)
+	prev := m.cursor
+	m.cursor = m.selectable(clamp(m.cursor+n, 0, len(m.rows)-1), 1)
+	n = max(m.cursor-prev, 0)
 	m.UpdateViewport()
 	yOffset := m.viewport.YOffset()
 	height := m.viewport.Height()
@@ -485,6 +524,9 @@ func (m Model) headersView() string {
 }
 
 func (m *Model) renderRow(r int) string {
+	if m.headerRows[r] {
+		return m.renderHeaderRow(r)
+	}
 	highlightRow := r == m.cursor
 	rowStyle := m.styles.Cell
 	if highlightRow {
@@ -511,6 +553,24 @@ func (m *Model) renderRow(r int) string {
 	return lipgloss.JoinHorizontal(lipgloss.Top, addSpacing(s)...)
 }
 
+// renderHeaderRow renders the first cell of row r across the table width.
+func (m *Model) renderHeaderRow(r int) string {
+	width := 0
+	for _, col := range m.cols {
+		if col.Width > 0 {
+			width += col.Width + m.styles.Cell.GetHorizontalPadding() + 1
+		}
+	}
+	width = max(width-1, 0)
+	var value string
+	if len(m.rows[r]) > 0 {
+		value = m.rows[r][0]
+	}
+	style := m.styles.Group
+	inner := max(width-style.GetHorizontalPadding(), 0)
+	return style.Width(width).MaxWidth(width).Inline(true).Render(ansi.Truncate(value, inner, "…"))
+}
+
 func clamp(v, low, high int) int {
 	return min(max(v, low), high)
 }
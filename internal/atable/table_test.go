@@ -0,0 +1,43 @@
+package table
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHeaderRowsAreSkipped(t *testing.T) {
+	m := New(
+		WithColumns([]Column{{Title: "ID", Width: 4}, {Title: "Description", Width: 20}}),
+		WithRows([]Row{{"Group A"}, {"1", "one"}, {"2", "two"}, {"Group B"}, {"3", "three"}}),
+		WithHeight(10),
+		WithWidth(40),
+		WithFocused(true),
+	)
+	m.SetHeaderRows([]int{0, 3})
+	if m.Cursor() != 1 {
+		t.Fatalf("cursor = %d, want the first task row", m.Cursor())
+	}
+	m.MoveDown(1)
+	m.MoveDown(1)
+	if m.Cursor() != 4 {
+		t.Fatalf("cursor = %d, want the header skipped", m.Cursor())
+	}
+	m.MoveUp(1)
+	if m.Cursor() != 2 {
+		t.Fatalf("cursor = %d, want the header skipped", m.Cursor())
+	}
+	m.GotoTop()
+	if m.Cursor() != 1 {
+		t.Fatalf("cursor = %d after GotoTop", m.Cursor())
+	}
+	m.SetCursor(3)
+	if m.Cursor() != 4 {
+		t.Fatalf("SetCursor on a header = %d, want the next row", m.Cursor())
+	}
+	if !m.IsHeaderRow(3) || m.IsHeaderRow(4) {
+		t.Fatal("unexpected header rows")
+	}
+	if view := m.View(); !strings.Contains(view, "Group B") || !strings.Contains(view, "three") {
+		t.Fatalf("unexpected view:\n%s", view)
+	}
+}
@@ -0,0 +1,205 @@
+// Package config loads the optional Task Samurai configuration file.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/google/shlex"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// Config holds user settings read from the configuration file. Every field
+// is optional; a missing file yields the zero Config.
+type Config struct {
+	Templates   []Template        `json:"templates"`
+	Board       Board             `json:"board"`
+	Rules       []Rule            `json:"rules"`
+	TagProjects map[string]string `json:"tag_projects"`
+	Enrich      []EnrichRule      `json:"enrich"`
+	QuickAdd    bool              `json:"quick_add"` // parse the add prompt as natural language
+	Inbox       string            `json:"inbox"`     // filter of the inbox wizard, default "project:"
+}
+
+// EnrichRule suggests modifiers for a new task: when the add line matches
+// the regular expression Match, the add prompt offers Add, in which $1 or
+// ${name} expand to the groups of the match (e.g. "project:$1" for an @file
+// path). Name defaults to Add.
+type EnrichRule struct {
+	Name  string `json:"name,omitempty"`
+	Match string `json:"match"`
+	Add   string `json:"add"`
+}
+
+// Rule is a named tag and project rewrite rule, for example
+// `tag:inbox + desc~"^PR " -> project:review -tag:inbox`; see
+// task.RewriteRule for the syntax. Name defaults to the rule itself.
+type Rule struct {
+	Name string `json:"name,omitempty"`
+	Rule string `json:"rule"`
+}
+
+// RewriteRules returns the configured rules followed by one rule per
+// TagProjects entry, which moves tasks with the tag into the project and
+// drops the tag.
+func (c Config) RewriteRules() []Rule {
+	rules := slices.Clone(c.Rules)
+	tags := make([]string, 0, len(c.TagProjects))
+	for tag := range c.TagProjects {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	for _, tag := range tags {
+		rules = append(rules, Rule{
+			Name: fmt.Sprintf("+%s → project:%s", tag, c.TagProjects[tag]),
+			Rule: fmt.Sprintf("tag:%s -> project:%s -tag:%s", tag, c.TagProjects[tag], tag),
+		})
+	}
+	return rules
+}
+
+// Template is a named blueprint for creating a task. Line is the
+// Taskwarrior add line (e.g. "project:dev +bug pri:H "), and each entry in
+// Annotations is added to the new task as a separate annotation. Both are
+// text/template strings; see the ui package for the available placeholders.
+type Template struct {
+	Name        string   `json:"name"`
+	Line        string   `json:"line"`
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// Board defines the lanes of the kanban board. By selects what a lane
+// stands for: "status" (lanes pending, started and waiting),
+// "tag" (each lane is a tag) or "uda" (each lane is a value of the UDA
+// named by UDA). An empty lane value collects the tasks that have none of
+// the other lanes' tags, or no value for the UDA.
+type Board struct {
+	By    string   `json:"by"`
+	UDA   string   `json:"uda,omitempty"`
+	Lanes []string `json:"lanes"`
+}
+
+// DefaultBoard is used when the configuration defines no board.
+var DefaultBoard = Board{By: "status", Lanes: []string{"pending", "started", "waiting"}}
+
+// boardStatuses are the lanes a board by status can have.
+var boardStatuses = []string{"pending", "started", "waiting"}
+
+// DefaultTemplates are offered when the configuration defines none.
+var DefaultTemplates = []Template{
+	{
+		Name:        "bug",
+		Line:        "project:dev +bug pri:H ",
+		Annotations: []string{"Steps to reproduce:", "Expected:", "Actual:"},
+	},
+	{
+		Name: "meeting-followup",
+		Line: "{{with .Selected.Project}}project:{{.}} {{end}}+followup due:{{tomorrow}} ",
+	},
+}
+
+// DefaultPath returns the default location of the configuration file,
+// $XDG_CONFIG_HOME/tasksamurai/config.json or its platform equivalent.
+func DefaultPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "tasksamurai", "config.json"), nil
+}
+
+// Load reads and validates the configuration at path. A missing file is not
+// an error and returns the zero Config.
+func Load(path string) (Config, error) {
+	var cfg Config
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return cfg, err
+	}
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return cfg, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+func (c Config) validate() error {
+	seen := make(map[string]bool, len(c.Templates))
+	for i, tmpl := range c.Templates {
+		name := strings.TrimSpace(tmpl.Name)
+		if name == "" {
+			return fmt.Errorf("template %d has no name", i+1)
+		}
+		if seen[name] {
+			return fmt.Errorf("duplicate template name %q", name)
+		}
+		seen[name] = true
+	}
+	for tag, project := range c.TagProjects {
+		if tag == "" || strings.ContainsAny(tag, " :") {
+			return fmt.Errorf("tag_projects: invalid tag %q", tag)
+		}
+		if strings.ContainsAny(project, " ") {
+			return fmt.Errorf("tag_projects: invalid project %q for tag %q", project, tag)
+		}
+	}
+	for i, rule := range c.Enrich {
+		if _, err := regexp.Compile(rule.Match); err != nil || rule.Match == "" {
+			return fmt.Errorf("enrich rule %d: invalid match %q", i+1, rule.Match)
+		}
+		if strings.TrimSpace(rule.Add) == "" {
+			return fmt.Errorf("enrich rule %d adds nothing", i+1)
+		}
+	}
+	if _, err := shlex.Split(c.Inbox); err != nil {
+		return fmt.Errorf("inbox filter %q: %w", c.Inbox, err)
+	}
+	for _, rule := range c.RewriteRules() {
+		if _, err := task.ParseRewriteRule(rule.Rule); err != nil {
+			return err
+		}
+	}
+	return c.Board.validate()
+}
+
+func (b Board) validate() error {
+	if b.By == "" && len(b.Lanes) == 0 {
+		return nil
+	}
+	switch b.By {
+	case "status", "tag":
+	case "uda":
+		if strings.TrimSpace(b.UDA) == "" {
+			return fmt.Errorf("board by uda needs the uda name")
+		}
+	default:
+		return fmt.Errorf("board by %q: want status, tag or uda", b.By)
+	}
+	if len(b.Lanes) == 0 {
+		return fmt.Errorf("board has no lanes")
+	}
+	seen := make(map[string]bool, len(b.Lanes))
+	for _, lane := range b.Lanes {
+		if seen[lane] {
+			return fmt.Errorf("duplicate board lane %q", lane)
+		}
+		seen[lane] = true
+		if b.By == "status" && !slices.Contains(boardStatuses, lane) {
+			return fmt.Errorf("board lane %q: want one of %s", lane, strings.Join(boardStatuses, ", "))
+		}
+	}
+	return nil
+}
@@ -0,0 +1,189 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadMissingFile(t *testing.T) {
+	cfg, err := Load(filepath.Join(t.TempDir(), "missing.json"))
+	if err != nil {
+		t.Fatalf("expected missing file to be ignored, got %v", err)
+	}
+	if len(cfg.Templates) != 0 {
+		t.Fatalf("expected empty config, got %+v", cfg)
+	}
+}
+
+func TestLoadTemplates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"templates": [{"name": "bug", "line": "project:dev +bug", "annotations": ["Steps:"]}]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.Templates) != 1 || cfg.Templates[0].Name != "bug" || cfg.Templates[0].Annotations[0] != "Steps:" {
+		t.Fatalf("unexpected templates: %+v", cfg.Templates)
+	}
+}
+
+func TestLoadRejectsInvalidTemplates(t *testing.T) {
+	tests := map[string]string{
+		"parsing":   `{"templates": [`,
+		"no name":   `{"templates": [{"line": "x"}]}`,
+		"duplicate": `{"templates": [{"name": "a"}, {"name": "a"}]}`,
+	}
+	for want, data := range tests {
+		path := filepath.Join(t.TempDir(), "config.json")
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadBoard(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"board": {"by": "uda", "uda": "stage", "lanes": ["", "review", "qa"]}}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Board.By != "uda" || cfg.Board.UDA != "stage" || len(cfg.Board.Lanes) != 3 {
+		t.Fatalf("unexpected board: %+v", cfg.Board)
+	}
+}
+
+func TestLoadRejectsInvalidBoard(t *testing.T) {
+	tests := map[string]string{
+		"want status, tag or uda": `{"board": {"by": "project", "lanes": ["a"]}}`,
+		"needs the uda name":      `{"board": {"by": "uda", "lanes": ["a"]}}`,
+		"no lanes":                `{"board": {"by": "tag"}}`,
+		"duplicate board lane":    `{"board": {"by": "tag", "lanes": ["a", "a"]}}`,
+		"want one of pending":     `{"board": {"by": "status", "lanes": ["done"]}}`,
+		"started, waiting":        `{"board": {"by": "status", "lanes": ["completed"]}}`,
+	}
+	for want, data := range tests {
+		path := filepath.Join(t.TempDir(), "config.json")
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{
+		"rules": [{"name": "review PRs", "rule": "tag:inbox + desc~\"^PR \" -> project:review -tag:inbox"}],
+		"tag_projects": {"work": "job", "home": "house"}
+	}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	rules := cfg.RewriteRules()
+	if len(rules) != 3 || rules[0].Name != "review PRs" {
+		t.Fatalf("unexpected rules: %+v", rules)
+	}
+	if rules[1].Rule != "tag:home -> project:house -tag:home" {
+		t.Fatalf("unexpected tag_projects rule: %+v", rules[1])
+	}
+}
+
+func TestLoadRejectsInvalidRules(t *testing.T) {
+	tests := map[string]string{
+		"want one \"->\"":      `{"rules": [{"rule": "tag:inbox project:review"}]}`,
+		"unknown modification": `{"rules": [{"rule": "tag:inbox -> due:today"}]}`,
+		"invalid tag":          `{"tag_projects": {"a b": "c"}}`,
+	}
+	for want, data := range tests {
+		path := filepath.Join(t.TempDir(), "config.json")
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadEnrichRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	data := `{"enrich": [{"name": "GitHub link", "match": "https://github\\.com/", "add": "+review"}]}`
+	if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.Enrich) != 1 || cfg.Enrich[0].Match != `https://github\.com/` || cfg.Enrich[0].Add != "+review" {
+		t.Fatalf("unexpected enrich rules: %+v", cfg.Enrich)
+	}
+
+	for want, data := range map[string]string{
+		"invalid match": `{"enrich": [{"match": "(", "add": "+x"}]}`,
+		"adds nothing":  `{"enrich": [{"match": "call"}]}`,
+	} {
+		if err := os.WriteFile(path, []byte(data), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Load(path); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("expected error containing %q, got %v", want, err)
+		}
+	}
+}
+
+func TestLoadQuickAdd(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"quick_add": true}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !cfg.QuickAdd {
+		t.Fatal("expected quick add to be enabled")
+	}
+}
+
+func TestLoadInboxFilter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"inbox": "+inbox or project:"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Inbox != "+inbox or project:" {
+		t.Fatalf("inbox = %q", cfg.Inbox)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"inbox": "description:\"open"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil || !strings.Contains(err.Error(), "inbox filter") {
+		t.Fatalf("expected an inbox filter error, got %v", err)
+	}
+}
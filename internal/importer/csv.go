@@ -0,0 +1,133 @@
+package importer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// csvColumns maps header names (lower-cased) to task fields. A header may
+// also name the field explicitly as "<anything>:<field>", e.g.
+// "Summary:description", to map columns with unusual names.
+var csvColumns = map[string]string{
+	"description": "description",
+	"desc":        "description",
+	"title":       "description",
+	"name":        "description",
+	"summary":     "description",
+	"task":        "description",
+	"project":     "project",
+	"list":        "project",
+	"tags":        "tags",
+	"tag":         "tags",
+	"labels":      "tags",
+	"context":     "tags",
+	"priority":    "priority",
+	"pri":         "priority",
+	"due":         "due",
+	"due date":    "due",
+	"deadline":    "due",
+	"notes":       "annotation",
+	"note":        "annotation",
+	"annotation":  "annotation",
+}
+
+// ParseCSV reads CSV with a header row that maps columns to task fields
+// (see csvColumns). Unknown columns are ignored; a description column is
+// required. Tags are split on commas, semicolons and whitespace, and a
+// non-empty notes column becomes an annotation.
+func ParseCSV(r io.Reader) ([]task.Task, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]string, len(header))
+	hasDescription := false
+	for i, name := range header {
+		fields[i] = csvField(name)
+		hasDescription = hasDescription || fields[i] == "description"
+	}
+	if !hasDescription {
+		return nil, fmt.Errorf("CSV header has no description column (got %q)", strings.Join(header, ","))
+	}
+
+	var tasks []task.Task
+	for {
+		record, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		line, _ := cr.FieldPos(0)
+		t, err := csvTask(fields, record)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		if t.Description != "" {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, nil
+}
+
+func csvField(header string) string {
+	name := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(header, "\ufeff")))
+	if i := strings.LastIndex(name, ":"); i >= 0 {
+		name = strings.TrimSpace(name[i+1:])
+	}
+	return csvColumns[name]
+}
+
+func csvTask(fields, record []string) (task.Task, error) {
+	var t task.Task
+	for i, value := range record {
+		if i >= len(fields) {
+			break
+		}
+		value = strings.TrimSpace(value)
+		if value == "" {
+			continue
+		}
+		switch fields[i] {
+		case "description":
+			t.Description = value
+		case "project":
+			t.Project = strings.ReplaceAll(value, " ", "_")
+		case "tags":
+			for _, tag := range strings.FieldsFunc(value, func(r rune) bool {
+				return r == ',' || r == ';' || unicode.IsSpace(r)
+			}) {
+				t.Tags = append(t.Tags, strings.TrimLeft(tag, "+@#"))
+			}
+		case "priority":
+			p, err := parsePriority(value)
+			if err != nil {
+				return task.Task{}, err
+			}
+			t.Priority = p
+		case "due":
+			due, err := parseDate(value)
+			if err != nil {
+				return task.Task{}, err
+			}
+			t.Due = due
+		case "annotation":
+			t.Annotations = append(t.Annotations, task.Annotation{Description: value})
+		}
+	}
+	return t, nil
+}
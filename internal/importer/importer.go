@@ -0,0 +1,179 @@
+// Package importer converts task lists from other tools (todo.txt, CSV and
+// Markdown checklists) into task.Task values for "task import".
+package importer
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// Format identifies an import file format.
+type Format string
+
+const (
+	FormatTodoTxt  Format = "todotxt"
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "markdown"
+)
+
+// Formats lists the supported formats.
+var Formats = []Format{FormatTodoTxt, FormatCSV, FormatMarkdown}
+
+// ParseFormat resolves a format name as given on the command line.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "todotxt", "todo.txt", "todo", "txt":
+		return FormatTodoTxt, nil
+	case "csv":
+		return FormatCSV, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
+	}
+	return "", fmt.Errorf("unknown import format %q (want todotxt, csv or markdown)", name)
+}
+
+// FormatFromPath guesses the format from a file name.
+func FormatFromPath(path string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".txt":
+		return FormatTodoTxt, nil
+	case ".csv":
+		return FormatCSV, nil
+	case ".md", ".markdown":
+		return FormatMarkdown, nil
+	}
+	return "", fmt.Errorf("cannot tell the format of %q from its extension; pass it explicitly", path)
+}
+
+// Parse reads tasks in the given format. Completed items (todo.txt "x"
+// lines, checked Markdown boxes) are skipped.
+func Parse(format Format, r io.Reader) ([]task.Task, error) {
+	switch format {
+	case FormatTodoTxt:
+		return ParseTodoTxt(r)
+	case FormatCSV:
+		return ParseCSV(r)
+	case FormatMarkdown:
+		return ParseMarkdown(r)
+	}
+	return nil, fmt.Errorf("unknown import format %q", format)
+}
+
+// Duplicates reports for each task whether its description matches an
+// existing task or an earlier task in the same import. Descriptions are
+// compared case-insensitively with whitespace collapsed.
+func Duplicates(tasks, existing []task.Task) []bool {
+	seen := make(map[string]bool, len(existing)+len(tasks))
+	for _, t := range existing {
+		seen[normalize(t.Description)] = true
+	}
+	dups := make([]bool, len(tasks))
+	for i, t := range tasks {
+		key := normalize(t.Description)
+		dups[i] = seen[key]
+		seen[key] = true
+	}
+	return dups
+}
+
+func normalize(desc string) string {
+	return strings.ToLower(strings.Join(strings.Fields(desc), " "))
+}
+
+// Accepted input date layouts, tried in order. Dates without a time are
+// taken as local midnight.
+var dateLayouts = []string{
+	"2006-01-02",
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	time.RFC3339,
+	task.DateFormat,
+}
+
+// parseDate converts a date to Taskwarrior's UTC date format.
+func parseDate(s string) (string, error) {
+	for _, layout := range dateLayouts {
+		ts, err := time.ParseInLocation(layout, s, time.Local)
+		if err == nil {
+			return ts.UTC().Format(task.DateFormat), nil
+		}
+	}
+	return "", fmt.Errorf("invalid date %q (want YYYY-MM-DD)", s)
+}
+
+// parsePriority maps H/M/L, their long names and todo.txt letters to
+// Taskwarrior priorities: A is high, B medium and anything below low.
+func parsePriority(s string) (string, error) {
+	switch p := strings.ToUpper(strings.TrimSpace(s)); p {
+	case "":
+		return "", nil
+	case "H", "HIGH", "A":
+		return "H", nil
+	case "M", "MEDIUM", "B":
+		return "M", nil
+	case "L", "LOW":
+		return "L", nil
+	default:
+		if len(p) == 1 && p[0] >= 'C' && p[0] <= 'Z' {
+			return "L", nil
+		}
+		return "", fmt.Errorf("invalid priority %q", s)
+	}
+}
+
+// WritePreview writes tasks as an aligned table, marking duplicates (see
+// Duplicates) in the DUP column. dups may be nil.
+func WritePreview(w io.Writer, tasks []task.Task, dups []bool) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tDUP\tPRI\tPROJECT\tTAGS\tDUE\tDESCRIPTION")
+	for i, t := range tasks {
+		dup := ""
+		if i < len(dups) && dups[i] {
+			dup = "dup"
+		}
+		due := ""
+		if ts, err := time.Parse(task.DateFormat, t.Due); err == nil {
+			due = ts.Local().Format("2006-01-02")
+		}
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			i+1, dup, t.Priority, t.Project, strings.Join(t.Tags, " "), due, t.Description)
+	}
+	return tw.Flush()
+}
+
+// Load parses the file at path. An empty format is guessed from the file
+// extension.
+func Load(path string, format Format) ([]task.Task, error) {
+	if format == "" {
+		var err error
+		if format, err = FormatFromPath(path); err != nil {
+			return nil, err
+		}
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Parse(format, f)
+}
+
+// WithoutDuplicates returns the tasks not flagged in dups.
+func WithoutDuplicates(tasks []task.Task, dups []bool) []task.Task {
+	kept := make([]task.Task, 0, len(tasks))
+	for i, t := range tasks {
+		if i >= len(dups) || !dups[i] {
+			kept = append(kept, t)
+		}
+	}
+	return kept
+}
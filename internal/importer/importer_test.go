@@ -0,0 +1,125 @@
+package importer
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func localDate(t *testing.T, s string) string {
+	t.Helper()
+	ts, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ts.UTC().Format(task.DateFormat)
+}
+
+func TestParseTodoTxt(t *testing.T) {
+	input := `(A) 2026-01-02 Call Mom +family +phone @home due:2026-02-01
+x 2026-01-03 Already done
+Plain task key:value
+
+(D) Someday @errands
+`
+	got, err := ParseTodoTxt(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseTodoTxt: %v", err)
+	}
+	want := []task.Task{
+		{Description: "Call Mom", Priority: "H", Entry: localDate(t, "2026-01-02"), Project: "family", Tags: []string{"phone", "home"}, Due: localDate(t, "2026-02-01")},
+		{Description: "Plain task key:value"},
+		{Description: "Someday", Priority: "L", Tags: []string{"errands"}},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseTodoTxt = %+v, want %+v", got, want)
+	}
+
+	if _, err := ParseTodoTxt(strings.NewReader("Fix due:soon")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Fatalf("expected a line error for a bad due date, got %v", err)
+	}
+}
+
+func TestParseCSV(t *testing.T) {
+	input := "Title,List,Labels,Priority,Due Date,Notes,Ignored\n" +
+		"Write report,Work Stuff,\"urgent, #q3\",high,2026-03-01,see wiki,x\n" +
+		",,,,,,\n" +
+		"Water plants,,,,,,\n"
+	got, err := ParseCSV(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseCSV: %v", err)
+	}
+	want := []task.Task{
+		{Description: "Write report", Project: "Work_Stuff", Tags: []string{"urgent", "q3"}, Priority: "H", Due: localDate(t, "2026-03-01"), Annotations: []task.Annotation{{Description: "see wiki"}}},
+		{Description: "Water plants"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseCSV = %+v, want %+v", got, want)
+	}
+
+	got, err = ParseCSV(strings.NewReader("Headline:description,Owner\nShip it,bob\n"))
+	if err != nil || len(got) != 1 || got[0].Description != "Ship it" {
+		t.Fatalf("explicit mapping: %+v, %v", got, err)
+	}
+	if _, err := ParseCSV(strings.NewReader("Owner\nbob\n")); err == nil {
+		t.Fatalf("expected an error without a description column")
+	}
+	if _, err := ParseCSV(strings.NewReader("title,priority\nx,urgent\n")); err == nil || !strings.Contains(err.Error(), "line 2") {
+		t.Fatalf("expected a line error for a bad priority, got %v", err)
+	}
+}
+
+func TestParseMarkdown(t *testing.T) {
+	input := `# Sprint
+- [ ] First
+  * [ ] Nested
+- [x] Done
+- plain bullet
+1. [ ] Numbered
+`
+	got, err := ParseMarkdown(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("ParseMarkdown: %v", err)
+	}
+	want := []task.Task{{Description: "First"}, {Description: "Nested"}, {Description: "Numbered"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("ParseMarkdown = %+v, want %+v", got, want)
+	}
+}
+
+func TestFormats(t *testing.T) {
+	for path, want := range map[string]Format{"todo.txt": FormatTodoTxt, "a.CSV": FormatCSV, "notes.md": FormatMarkdown} {
+		if got, err := FormatFromPath(path); err != nil || got != want {
+			t.Errorf("FormatFromPath(%q) = %q, %v", path, got, err)
+		}
+	}
+	if _, err := FormatFromPath("tasks.json"); err == nil {
+		t.Errorf("expected an error for an unknown extension")
+	}
+	if got, err := ParseFormat("md"); err != nil || got != FormatMarkdown {
+		t.Errorf("ParseFormat(md) = %q, %v", got, err)
+	}
+}
+
+func TestDuplicates(t *testing.T) {
+	existing := []task.Task{{Description: "Buy  Milk"}}
+	tasks := []task.Task{{Description: "buy milk"}, {Description: "Call Bob"}, {Description: "call bob"}}
+	if got, want := Duplicates(tasks, existing), []bool{true, false, true}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Duplicates = %v, want %v", got, want)
+	}
+}
+
+func TestWritePreview(t *testing.T) {
+	var b strings.Builder
+	tasks := []task.Task{{Description: "Buy milk", Project: "home", Due: localDate(t, "2026-05-06")}, {Description: "Other"}}
+	if err := WritePreview(&b, tasks, []bool{false, true}); err != nil {
+		t.Fatalf("WritePreview: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 || !strings.Contains(lines[1], "2026-05-06") || !strings.Contains(lines[2], "dup") {
+		t.Fatalf("unexpected preview:\n%s", b.String())
+	}
+}
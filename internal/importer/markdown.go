@@ -0,0 +1,34 @@
+package importer
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"strings"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// markdownItem matches a checklist item such as "- [ ] text", "* [x] text"
+// or "1. [ ] text", at any indentation.
+var markdownItem = regexp.MustCompile(`^\s*(?:[-*+]|\d+[.)])\s+\[([ xX])\]\s+(.+)$`)
+
+// ParseMarkdown reads the unchecked items of a Markdown checklist. Other
+// lines, including plain bullets and checked items, are ignored.
+func ParseMarkdown(r io.Reader) ([]task.Task, error) {
+	var tasks []task.Task
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		m := markdownItem.FindStringSubmatch(scanner.Text())
+		if m == nil || m[1] != " " {
+			continue
+		}
+		if desc := strings.TrimSpace(m[2]); desc != "" {
+			tasks = append(tasks, task.Task{Description: desc})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
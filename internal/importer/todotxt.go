@@ -0,0 +1,84 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+var (
+	todoPriority = regexp.MustCompile(`^\(([A-Z])\)\s+`)
+	todoDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}\s+`)
+)
+
+// ParseTodoTxt reads the todo.txt format: an optional "(A)" priority and
+// creation date, then the description with +project, @context and key:value
+// tokens. The first +project becomes the project and further ones tags, as
+// Taskwarrior has a single project per task; contexts become tags and due:
+// the due date. Other key:value pairs stay in the description.
+func ParseTodoTxt(r io.Reader) ([]task.Task, error) {
+	var tasks []task.Task
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "x ") {
+			continue
+		}
+		t, err := parseTodoLine(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		tasks = append(tasks, t)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return tasks, nil
+}
+
+func parseTodoLine(line string) (task.Task, error) {
+	var t task.Task
+	if m := todoPriority.FindStringSubmatch(line); m != nil {
+		t.Priority, _ = parsePriority(m[1])
+		line = line[len(m[0]):]
+	}
+	if m := todoDate.FindString(line); m != "" {
+		entry, err := parseDate(strings.TrimSpace(m))
+		if err != nil {
+			return task.Task{}, err
+		}
+		t.Entry = entry
+		line = line[len(m):]
+	}
+
+	var words []string
+	for _, word := range strings.Fields(line) {
+		switch {
+		case len(word) > 1 && word[0] == '+':
+			if t.Project == "" {
+				t.Project = word[1:]
+			} else {
+				t.Tags = append(t.Tags, word[1:])
+			}
+		case len(word) > 1 && word[0] == '@':
+			t.Tags = append(t.Tags, word[1:])
+		case strings.HasPrefix(word, "due:") && len(word) > len("due:"):
+			due, err := parseDate(strings.TrimPrefix(word, "due:"))
+			if err != nil {
+				return task.Task{}, err
+			}
+			t.Due = due
+		default:
+			words = append(words, word)
+		}
+	}
+	t.Description = strings.Join(words, " ")
+	if t.Description == "" {
+		return task.Task{}, fmt.Errorf("task has no description")
+	}
+	return t, nil
+}
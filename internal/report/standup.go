@@ -0,0 +1,195 @@
+// Package report builds text reports from exported tasks.
+package report
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// Activity is what happened to a task in the standup period. A task is
+// listed under the first matching activity only.
+type Activity int
+
+const (
+	Completed Activity = iota
+	Started
+	Annotated
+	Modified
+	activityCount
+)
+
+var activityTitles = [activityCount]string{
+	Completed: "Completed",
+	Started:   "Started",
+	Annotated: "Annotated",
+	Modified:  "Modified",
+}
+
+// noProject is the heading used for tasks without a project.
+const noProject = "(no project)"
+
+// Standup groups the tasks active since a point in time by project.
+type Standup struct {
+	Since    time.Time
+	Projects []StandupProject
+}
+
+// StandupProject lists a project's tasks per activity.
+type StandupProject struct {
+	Name  string
+	Tasks [activityCount][]task.Task
+}
+
+// ParseSince resolves the start of a standup period: "yesterday" (the
+// default for ""), "today", "Nd" for N days back, "monday" … "sunday" for
+// the most recent such day, or a YYYY-MM-DD date. Days start at local
+// midnight.
+func ParseSince(s string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "", "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "today":
+		return today, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n >= 0 {
+			return today.AddDate(0, 0, -n), nil
+		}
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		if s == strings.ToLower(wd.String()) {
+			back := (int(today.Weekday()) - int(wd) + 7) % 7
+			if back == 0 {
+				back = 7
+			}
+			return today.AddDate(0, 0, -back), nil
+		}
+	}
+	ts, err := time.ParseInLocation("2006-01-02", s, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q (use yesterday, today, 3d, monday or YYYY-MM-DD)", s)
+	}
+	return ts, nil
+}
+
+// Exporter is the part of task.Taskwarrior the report needs.
+type Exporter interface {
+	Export(ctx context.Context, filters ...string) ([]task.Task, error)
+}
+
+// LoadStandup exports every task modified since the given time, of any
+// status, and builds the standup from it.
+func LoadStandup(ctx context.Context, tw Exporter, since time.Time) (Standup, error) {
+	tasks, err := tw.Export(ctx, "status.any:", "modified.after:"+since.UTC().Format(task.DateFormat))
+	if err != nil {
+		return Standup{}, err
+	}
+	return BuildStandup(tasks, since), nil
+}
+
+// BuildStandup classifies tasks by what happened since the given time and
+// groups them by project. Deleted tasks and tasks without activity in the
+// period are left out.
+func BuildStandup(tasks []task.Task, since time.Time) Standup {
+	after := func(date string) bool {
+		ts, err := time.Parse(task.DateFormat, date)
+		return err == nil && !ts.Before(since)
+	}
+
+	byProject := make(map[string]*StandupProject)
+	for _, t := range tasks {
+		activity := activityCount
+		switch {
+		case t.Status == "deleted":
+			continue
+		case t.Status == "completed" && after(t.End):
+			activity = Completed
+		case after(t.Start):
+			activity = Started
+		case annotatedAfter(t, after):
+			activity = Annotated
+		case after(t.Modified):
+			activity = Modified
+		default:
+			continue
+		}
+		name := t.Project
+		if name == "" {
+			name = noProject
+		}
+		p := byProject[name]
+		if p == nil {
+			p = &StandupProject{Name: name}
+			byProject[name] = p
+		}
+		p.Tasks[activity] = append(p.Tasks[activity], t)
+	}
+
+	s := Standup{Since: since}
+	for _, p := range byProject {
+		s.Projects = append(s.Projects, *p)
+	}
+	sort.Slice(s.Projects, func(i, j int) bool {
+		a, b := s.Projects[i].Name, s.Projects[j].Name
+		if (a == noProject) != (b == noProject) {
+			return b == noProject
+		}
+		return a < b
+	})
+	return s
+}
+
+func annotatedAfter(t task.Task, after func(string) bool) bool {
+	for _, a := range t.Annotations {
+		if after(a.Entry) {
+			return true
+		}
+	}
+	return false
+}
+
+// Markdown renders the standup as Markdown, one section per project.
+func (s Standup) Markdown() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Standup since %s\n", s.Since.Format("Mon 2006-01-02"))
+	if len(s.Projects) == 0 {
+		b.WriteString("\nNothing completed, started or changed.\n")
+		return b.String()
+	}
+	for _, p := range s.Projects {
+		fmt.Fprintf(&b, "\n## %s\n", p.Name)
+		for activity, tasks := range p.Tasks {
+			if len(tasks) == 0 {
+				continue
+			}
+			fmt.Fprintf(&b, "\n%s:\n", activityTitles[activity])
+			for _, t := range tasks {
+				check := " "
+				if Activity(activity) == Completed {
+					check = "x"
+				}
+				fmt.Fprintf(&b, "- [%s] %s\n", check, t.Description)
+			}
+		}
+	}
+	return b.String()
+}
+
+// Count returns the number of tasks in the report.
+func (s Standup) Count() int {
+	n := 0
+	for _, p := range s.Projects {
+		for _, tasks := range p.Tasks {
+			n += len(tasks)
+		}
+	}
+	return n
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestParseSince(t *testing.T) {
+	now := time.Date(2026, 10, 14, 15, 30, 0, 0, time.UTC) // a Wednesday
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 0, 0, 0, 0, time.UTC) }
+	for in, want := range map[string]time.Time{
+		"":           day(13),
+		"yesterday":  day(13),
+		"Today":      day(14),
+		"3d":         day(11),
+		"monday":     day(12),
+		"wednesday":  day(7),
+		"2026-10-01": day(1),
+	} {
+		got, err := ParseSince(in, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("ParseSince(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+	if _, err := ParseSince("last week", now); err == nil {
+		t.Errorf("expected an error for an unknown date")
+	}
+}
+
+func TestBuildStandup(t *testing.T) {
+	since := time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)
+	before, after := "20261012T100000Z", "20261013T100000Z"
+	tasks := []task.Task{
+		{Description: "Shipped", Project: "dev", Status: "completed", End: after, Start: after, Modified: after},
+		{Description: "Old done", Project: "dev", Status: "completed", End: before, Modified: after},
+		{Description: "Working", Project: "dev", Status: "pending", Start: after, Modified: after},
+		{Description: "Noted", Status: "pending", Annotations: []task.Annotation{{Entry: after, Description: "x"}}, Modified: after},
+		{Description: "Tweaked", Project: "admin", Status: "pending", Modified: after},
+		{Description: "Gone", Project: "dev", Status: "deleted", Modified: after},
+		{Description: "Idle", Project: "dev", Status: "pending", Modified: before},
+	}
+	s := BuildStandup(tasks, since)
+
+	var names []string
+	for _, p := range s.Projects {
+		names = append(names, p.Name)
+	}
+	if want := []string{"admin", "dev", noProject}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("projects = %q, want %q", names, want)
+	}
+	dev := s.Projects[1]
+	if len(dev.Tasks[Completed]) != 1 || dev.Tasks[Completed][0].Description != "Shipped" {
+		t.Fatalf("dev completed = %+v", dev.Tasks[Completed])
+	}
+	if len(dev.Tasks[Started]) != 1 || len(dev.Tasks[Modified]) != 1 {
+		t.Fatalf("dev started/modified = %+v / %+v", dev.Tasks[Started], dev.Tasks[Modified])
+	}
+	if s.Count() != 5 {
+		t.Fatalf("Count = %d, want 5", s.Count())
+	}
+
+	md := s.Markdown()
+	for _, want := range []string{"# Standup since Tue 2026-10-13", "## dev\n\nCompleted:\n- [x] Shipped", "Started:\n- [ ] Working", "## (no project)\n\nAnnotated:\n- [ ] Noted"} {
+		if !strings.Contains(md, want) {
+			t.Fatalf("markdown missing %q:\n%s", want, md)
+		}
+	}
+	if empty := BuildStandup(nil, since).Markdown(); !strings.Contains(empty, "Nothing completed") {
+		t.Fatalf("empty report = %q", empty)
+	}
+}
+
+type fakeExporter struct{ filters []string }
+
+func (f *fakeExporter) Export(_ context.Context, filters ...string) ([]task.Task, error) {
+	f.filters = filters
+	return nil, nil
+}
+
+func TestLoadStandupFilters(t *testing.T) {
+	f := &fakeExporter{}
+	since := time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC)
+	if _, err := LoadStandup(context.Background(), f, since); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"status.any:", "modified.after:20261013T000000Z"}; !reflect.DeepEqual(f.filters, want) {
+		t.Fatalf("filters = %q, want %q", f.filters, want)
+	}
+}
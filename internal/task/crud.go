@@ -84,6 +84,21 @@ func SetStatusUUIDContext(ctx context.Context, uuid, status string) error {
 	return runContext(ctx, uuid, "modify", "status:"+status)
 }
 
+// ModifyUUIDContext runs "task <uuid> modify" with the given modifier
+// arguments, each passed as a separate command-line argument. Like
+// ModifySeriesContext it answers Taskwarrior's recurrence prompt with "no",
+// so a recurring template or instance changes on its own instead of waiting
+// on a question nobody can answer.
+func ModifyUUIDContext(ctx context.Context, uuid string, args []string) error {
+	if uuid == "" {
+		return fmt.Errorf("task UUID cannot be empty")
+	}
+	if len(args) == 0 {
+		return fmt.Errorf("no modifications given")
+	}
+	return runContext(ctx, append([]string{"rc.recurrence.confirmation=no", uuid, "modify"}, args...)...)
+}
+
 // Start begins the task with the given id.
 func Start(id int) error {
 	return StartContext(context.Background(), id)
@@ -354,6 +369,15 @@ func SetProjectContext(ctx context.Context, id int, project string) error {
 	return modifyTaskContext(ctx, id, "project:"+project)
 }
 
+// AddDependencyContext makes the task with the given id depend on the task
+// with the given UUID, keeping any existing dependencies.
+func AddDependencyContext(ctx context.Context, id int, uuid string) error {
+	if uuid == "" {
+		return fmt.Errorf("dependency UUID cannot be empty")
+	}
+	return modifyTaskContext(ctx, id, "depends:"+uuid)
+}
+
 // Annotate adds an annotation to the task with the given id.
 func Annotate(id int, text string) error {
 	return AnnotateContext(context.Background(), id, text)
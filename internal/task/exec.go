@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io"
 	"os/exec"
 	"strings"
 
@@ -50,6 +51,11 @@ func RunShellLine(ctx context.Context, line string) (RunResult, error) {
 
 // RunArgs runs "task" with args and captures stdout and stderr.
 func RunArgs(ctx context.Context, args []string) (RunResult, error) {
+	return runArgsInput(ctx, args, nil)
+}
+
+// runArgsInput is RunArgs with stdin connected to input (nil for none).
+func runArgsInput(ctx context.Context, args []string, input io.Reader) (RunResult, error) {
 	copied := append([]string(nil), args...)
 	result := RunResult{Args: copied}
 	if len(copied) == 0 {
@@ -64,6 +70,7 @@ func RunArgs(ctx context.Context, args []string) (RunResult, error) {
 
 	cmd := exec.CommandContext(ctx, "task", copied...)
 	configureCommandContext(cmd)
+	cmd.Stdin = input
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
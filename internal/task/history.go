@@ -0,0 +1,171 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// HistoryAction classifies a journal entry of "task info".
+type HistoryAction string
+
+const (
+	HistorySet     HistoryAction = "set"     // attribute given a value
+	HistoryChanged HistoryAction = "changed" // value replaced
+	HistoryDeleted HistoryAction = "deleted" // attribute removed
+	HistoryAdded   HistoryAction = "added"   // annotation added
+	HistoryRemoved HistoryAction = "removed" // annotation removed
+	HistoryOther   HistoryAction = ""        // anything not recognised; see Text
+)
+
+// HistoryEntry is one modification from the journal printed by "task info".
+// Taskwarrior does not record who made a change, only when.
+type HistoryEntry struct {
+	Time     time.Time
+	Field    string // lower-cased attribute, e.g. "priority"; "annotation" for annotations
+	Action   HistoryAction
+	Old      string
+	New      string
+	Duration string // for "start deleted": the ISO 8601 duration of the interval
+	Text     string // the original journal sentence
+}
+
+// Interval is a period during which a task was started. Stop is zero while
+// the task is still active.
+type Interval struct {
+	Start time.Time
+	Stop  time.Time
+}
+
+// historyDateLayout matches the rc.dateformat.info forced by History.
+const historyDateLayout = "2006-01-02 15:04:05"
+
+var (
+	historyChanged    = regexp.MustCompile(`^(.+?) changed from '(.*)' to '(.*)'\.$`)
+	historySet        = regexp.MustCompile(`^(.+?) set to '(.*)'\.$`)
+	historyDeleted    = regexp.MustCompile(`^(.+?) deleted(?: \(duration: (.*)\))?\.$`)
+	historyAnnotation = regexp.MustCompile(`^Annotation of '(.*)' (added|deleted)\.$`)
+)
+
+// History returns the modification journal of the task with the given UUID,
+// oldest first.
+func History(ctx context.Context, uuid string) ([]HistoryEntry, error) {
+	if strings.TrimSpace(uuid) == "" {
+		return nil, fmt.Errorf("task UUID cannot be empty")
+	}
+	result, err := RunArgs(ctx, []string{
+		"rc.color=off",
+		"rc.defaultwidth=0",
+		"rc.journal.info=on",
+		"rc.dateformat.info=Y-M-D H:N:S",
+		uuid, "info",
+	})
+	if err != nil {
+		return nil, err
+	}
+	return parseHistory(result.Stdout, time.Local), nil
+}
+
+// parseHistory extracts the "Date  Modification" table from "task info"
+// output. Lines without a date continue the previous timestamp: a line
+// completing an unfinished sentence is joined to it, any other line is a
+// further modification made at the same time.
+func parseHistory(out string, loc *time.Location) []HistoryEntry {
+	lines := strings.Split(out, "\n")
+	start := -1
+	for i, line := range lines {
+		fields := strings.Fields(line)
+		if len(fields) == 2 && fields[0] == "Date" && fields[1] == "Modification" {
+			start = i + 1
+			break
+		}
+	}
+	if start < 0 {
+		return nil
+	}
+
+	var (
+		entries []HistoryEntry
+		texts   []string
+		current time.Time
+	)
+	for _, line := range lines[start:] {
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		if strings.Trim(line, "- ") == "" {
+			continue // header underline
+		}
+		text := strings.TrimSpace(line)
+		if len(line) >= len(historyDateLayout) {
+			if ts, err := time.ParseInLocation(historyDateLayout, line[:len(historyDateLayout)], loc); err == nil {
+				current = ts
+				text = strings.TrimSpace(line[len(historyDateLayout):])
+				entries = append(entries, HistoryEntry{Time: current})
+				texts = append(texts, text)
+				continue
+			}
+		}
+		if current.IsZero() {
+			continue
+		}
+		if n := len(texts); n > 0 && !strings.HasSuffix(texts[n-1], ".") {
+			texts[n-1] += " " + text
+			continue
+		}
+		entries = append(entries, HistoryEntry{Time: current})
+		texts = append(texts, text)
+	}
+	for i := range entries {
+		entries[i] = classifyHistory(entries[i], texts[i])
+	}
+	return entries
+}
+
+func classifyHistory(e HistoryEntry, text string) HistoryEntry {
+	e.Text = text
+	if m := historyAnnotation.FindStringSubmatch(text); m != nil {
+		e.Field = "annotation"
+		if m[2] == "added" {
+			e.Action, e.New = HistoryAdded, m[1]
+		} else {
+			e.Action, e.Old = HistoryRemoved, m[1]
+		}
+		return e
+	}
+	if m := historyChanged.FindStringSubmatch(text); m != nil {
+		e.Field, e.Action, e.Old, e.New = strings.ToLower(m[1]), HistoryChanged, m[2], m[3]
+		return e
+	}
+	if m := historySet.FindStringSubmatch(text); m != nil {
+		e.Field, e.Action, e.New = strings.ToLower(m[1]), HistorySet, m[2]
+		return e
+	}
+	if m := historyDeleted.FindStringSubmatch(text); m != nil {
+		e.Field, e.Action, e.Duration = strings.ToLower(m[1]), HistoryDeleted, m[2]
+		return e
+	}
+	e.Action = HistoryOther
+	return e
+}
+
+// StartStopIntervals pairs the start and stop events in a journal.
+func StartStopIntervals(entries []HistoryEntry) []Interval {
+	var intervals []Interval
+	for _, e := range entries {
+		if e.Field != "start" {
+			continue
+		}
+		switch e.Action {
+		case HistorySet, HistoryChanged:
+			intervals = append(intervals, Interval{Start: e.Time})
+		case HistoryDeleted:
+			if n := len(intervals); n > 0 && intervals[n-1].Stop.IsZero() {
+				intervals[n-1].Stop = e.Time
+			}
+		}
+	}
+	return intervals
+}
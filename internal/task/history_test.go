@@ -0,0 +1,56 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+const sampleInfo = `
+Name          Value
+------------- ------------------------------------
+ID            3
+Description   Write report
+Status        Pending
+
+Date                Modification
+------------------- ------------------------------------------------------------
+2026-01-02 10:00:00 Priority set to 'M'.
+2026-01-03 09:00:00 Start set to '2026-01-03 09:00:00'.
+                    Priority changed from 'M' to 'H'.
+2026-01-03 10:30:00 Start deleted (duration: PT1H30M).
+2026-01-04 08:00:00 Annotation of 'a long note that was
+                    wrapped' added.
+2026-01-04 08:05:00 Tags changed from 'a' to 'a b'.
+2026-01-05 12:00:00 Something new happened.
+2026-01-06 07:00:00 Start set to '2026-01-06 07:00:00'.
+`
+
+func TestParseHistory(t *testing.T) {
+	loc := time.UTC
+	at := func(day, hour, minute int) time.Time { return time.Date(2026, 1, day, hour, minute, 0, 0, loc) }
+	got := parseHistory(sampleInfo, loc)
+	want := []HistoryEntry{
+		{Time: at(2, 10, 0), Field: "priority", Action: HistorySet, New: "M", Text: "Priority set to 'M'."},
+		{Time: at(3, 9, 0), Field: "start", Action: HistorySet, New: "2026-01-03 09:00:00", Text: "Start set to '2026-01-03 09:00:00'."},
+		{Time: at(3, 9, 0), Field: "priority", Action: HistoryChanged, Old: "M", New: "H", Text: "Priority changed from 'M' to 'H'."},
+		{Time: at(3, 10, 30), Field: "start", Action: HistoryDeleted, Duration: "PT1H30M", Text: "Start deleted (duration: PT1H30M)."},
+		{Time: at(4, 8, 0), Field: "annotation", Action: HistoryAdded, New: "a long note that was wrapped", Text: "Annotation of 'a long note that was wrapped' added."},
+		{Time: at(4, 8, 5), Field: "tags", Action: HistoryChanged, Old: "a", New: "a b", Text: "Tags changed from 'a' to 'a b'."},
+		{Time: at(5, 12, 0), Action: HistoryOther, Text: "Something new happened."},
+		{Time: at(6, 7, 0), Field: "start", Action: HistorySet, New: "2026-01-06 07:00:00", Text: "Start set to '2026-01-06 07:00:00'."},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseHistory =\n%+v\nwant\n%+v", got, want)
+	}
+
+	intervals := StartStopIntervals(got)
+	wantIntervals := []Interval{{Start: at(3, 9, 0), Stop: at(3, 10, 30)}, {Start: at(6, 7, 0)}}
+	if !reflect.DeepEqual(intervals, wantIntervals) {
+		t.Fatalf("StartStopIntervals = %+v, want %+v", intervals, wantIntervals)
+	}
+
+	if entries := parseHistory("Name Value\nID 1\n", loc); entries != nil {
+		t.Fatalf("expected no entries without a journal, got %+v", entries)
+	}
+}
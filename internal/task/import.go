@@ -0,0 +1,71 @@
+package task
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// importRecord is the subset of task attributes sent to "task import".
+// Empty attributes are omitted so Taskwarrior fills in its own defaults,
+// including a fresh UUID.
+type importRecord struct {
+	Description string       `json:"description"`
+	Status      string       `json:"status"`
+	Entry       string       `json:"entry"`
+	Project     string       `json:"project,omitempty"`
+	Tags        []string     `json:"tags,omitempty"`
+	Priority    string       `json:"priority,omitempty"`
+	Due         string       `json:"due,omitempty"`
+	Annotations []Annotation `json:"annotations,omitempty"`
+}
+
+// importJSON encodes tasks as the JSON array read by "task import". Tasks
+// without a status or entry date are imported as pending and created now.
+func importJSON(tasks []Task, now time.Time) ([]byte, error) {
+	records := make([]importRecord, 0, len(tasks))
+	for _, t := range tasks {
+		if t.Description == "" {
+			return nil, fmt.Errorf("task description cannot be empty")
+		}
+		rec := importRecord{
+			Description: t.Description,
+			Status:      t.Status,
+			Entry:       t.Entry,
+			Project:     t.Project,
+			Tags:        t.Tags,
+			Priority:    t.Priority,
+			Due:         t.Due,
+		}
+		if rec.Status == "" {
+			rec.Status = "pending"
+		}
+		if rec.Entry == "" {
+			rec.Entry = now.UTC().Format(DateFormat)
+		}
+		rec.Annotations = append([]Annotation(nil), t.Annotations...)
+		for i := range rec.Annotations {
+			if rec.Annotations[i].Entry == "" {
+				rec.Annotations[i].Entry = rec.Entry
+			}
+		}
+		records = append(records, rec)
+	}
+	return json.Marshal(records)
+}
+
+// ImportContext creates tasks with a single "task import" call, passing
+// them as JSON on stdin.
+func ImportContext(ctx context.Context, tasks []Task) error {
+	if len(tasks) == 0 {
+		return fmt.Errorf("no tasks to import")
+	}
+	data, err := importJSON(tasks, time.Now())
+	if err != nil {
+		return err
+	}
+	_, err = runArgsInput(ctx, []string{"import", "-"}, bytes.NewReader(data))
+	return err
+}
@@ -0,0 +1,77 @@
+package task
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestImportJSON(t *testing.T) {
+	now := time.Date(2026, 3, 4, 5, 6, 7, 0, time.UTC)
+	data, err := importJSON([]Task{
+		{Description: "Buy milk", Project: "home", Tags: []string{"shop"}, Priority: "H", Due: "20260305T000000Z"},
+		{Description: "Done already", Status: "completed", Entry: "20260101T000000Z"},
+	}, now)
+	if err != nil {
+		t.Fatalf("importJSON: %v", err)
+	}
+	var got []map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	want := []map[string]any{
+		{"description": "Buy milk", "status": "pending", "entry": "20260304T050607Z", "project": "home", "tags": []any{"shop"}, "priority": "H", "due": "20260305T000000Z"},
+		{"description": "Done already", "status": "completed", "entry": "20260101T000000Z"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("importJSON = %v, want %v", got, want)
+	}
+
+	if _, err := importJSON([]Task{{}}, now); err == nil {
+		t.Fatalf("expected an error for an empty description")
+	}
+}
+
+func TestImportContextPassesJSONOnStdin(t *testing.T) {
+	tmp := t.TempDir()
+	taskPath := filepath.Join(tmp, "task")
+	argsFile := filepath.Join(tmp, "args.txt")
+	stdinFile := filepath.Join(tmp, "stdin.json")
+
+	script := "#!/bin/sh\n" +
+		"printf '%s\\n' \"$@\" > " + argsFile + "\n" +
+		"cat > " + stdinFile + "\n"
+	if err := os.WriteFile(taskPath, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	origPath := os.Getenv("PATH")
+	_ = os.Setenv("PATH", tmp+":"+origPath)
+	t.Cleanup(func() { _ = os.Setenv("PATH", origPath) })
+
+	if err := ImportContext(context.Background(), []Task{{Description: "one"}}); err != nil {
+		t.Fatalf("ImportContext: %v", err)
+	}
+	args, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(args) != "import\n-\n" {
+		t.Fatalf("args = %q", args)
+	}
+	var records []map[string]any
+	data, err := os.ReadFile(stdinFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal(data, &records); err != nil || len(records) != 1 || records[0]["description"] != "one" {
+		t.Fatalf("stdin = %s (%v)", data, err)
+	}
+
+	if err := ImportContext(context.Background(), nil); err == nil {
+		t.Fatalf("expected an error for an empty import")
+	}
+}
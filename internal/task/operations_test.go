@@ -1,6 +1,7 @@
 package task
 
 import (
+	"context"
 	"strings"
 	"testing"
 )
@@ -52,6 +53,15 @@ func TestModifyTask(t *testing.T) {
 	}
 }
 
+func TestModifyUUIDValidation(t *testing.T) {
+	if err := ModifyUUIDContext(context.Background(), "", []string{"priority:H"}); err == nil || !strings.Contains(err.Error(), "UUID") {
+		t.Errorf("expected empty UUID error, got %v", err)
+	}
+	if err := ModifyUUIDContext(context.Background(), "abc", nil); err == nil || !strings.Contains(err.Error(), "no modifications") {
+		t.Errorf("expected no-modifications error, got %v", err)
+	}
+}
+
 func TestSimpleTaskCommand(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -0,0 +1,60 @@
+package task
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ProjectNode is a level of the dotted project hierarchy, such as "client"
+// in "work.client.api". Its counts include the tasks of its subprojects.
+type ProjectNode struct {
+	Name     string // the last segment, e.g. "client"
+	Path     string // the full project, e.g. "work.client"
+	Pending  int
+	Overdue  int
+	Children []*ProjectNode
+}
+
+// ProjectTree builds the project hierarchy of the pending tasks, with the
+// nodes of every level sorted by name. Tasks without a project are not
+// counted.
+func ProjectTree(tasks []Task, now time.Time) []*ProjectNode {
+	root := &ProjectNode{}
+	for _, t := range tasks {
+		if t.Project == "" || (t.Status != "" && t.Status != "pending") {
+			continue
+		}
+		overdue := 0
+		if ts, err := time.Parse(DateFormat, t.Due); err == nil && ts.Before(now) {
+			overdue = 1
+		}
+		node := root
+		segments := strings.Split(t.Project, ".")
+		for i, name := range segments {
+			node = node.child(name, strings.Join(segments[:i+1], "."))
+			node.Pending++
+			node.Overdue += overdue
+		}
+	}
+	root.sort()
+	return root.Children
+}
+
+func (n *ProjectNode) child(name, path string) *ProjectNode {
+	for _, c := range n.Children {
+		if c.Name == name {
+			return c
+		}
+	}
+	c := &ProjectNode{Name: name, Path: path}
+	n.Children = append(n.Children, c)
+	return c
+}
+
+func (n *ProjectNode) sort() {
+	sort.Slice(n.Children, func(i, j int) bool { return n.Children[i].Name < n.Children[j].Name })
+	for _, c := range n.Children {
+		c.sort()
+	}
+}
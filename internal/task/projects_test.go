@@ -0,0 +1,33 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestProjectTree(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tasks := []Task{
+		{Status: "pending", Project: "work.client.api", Due: "20261017T000000Z"},
+		{Status: "pending", Project: "work.client"},
+		{Status: "pending", Project: "work.admin", Due: "20261020T000000Z"},
+		{Status: "pending", Project: "home"},
+		{Status: "completed", Project: "home"},
+		{Status: "pending"},
+	}
+	tree := ProjectTree(tasks, now)
+	if len(tree) != 2 || tree[0].Name != "home" || tree[1].Name != "work" {
+		t.Fatalf("unexpected roots %+v", tree)
+	}
+	work := tree[1]
+	if work.Pending != 3 || work.Overdue != 1 || len(work.Children) != 2 {
+		t.Fatalf("work = %+v", work)
+	}
+	client := work.Children[1]
+	if client.Path != "work.client" || client.Pending != 2 || client.Overdue != 1 {
+		t.Fatalf("client = %+v", client)
+	}
+	if api := client.Children[0]; api.Path != "work.client.api" || api.Pending != 1 {
+		t.Fatalf("api = %+v", api)
+	}
+}
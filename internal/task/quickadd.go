@@ -0,0 +1,283 @@
+package task
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/shlex"
+)
+
+// quickPriorities maps the priority shorthands of a quick add line.
+var quickPriorities = map[string]string{
+	"!high": "H", "!h": "H", "!!!": "H",
+	"!medium": "M", "!med": "M", "!m": "M", "!!": "M",
+	"!low": "L", "!l": "L", "!": "L",
+}
+
+// quickUnits maps the units of "in 3 days" and "every 2 weeks" to the
+// Taskwarrior recurrence unit.
+var quickUnits = map[string]string{
+	"day": "days", "days": "days", "week": "weeks", "weeks": "weeks",
+	"month": "months", "months": "months", "year": "years", "years": "years",
+}
+
+// quickEvery maps "every X" to a Taskwarrior recurrence.
+var quickEvery = map[string]string{
+	"day": "daily", "weekday": "weekdays", "weekdays": "weekdays", "week": "weekly",
+	"month": "monthly", "quarter": "quarterly", "year": "yearly",
+}
+
+var (
+	quickTimeRegex = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	quickDateRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+	// quickHashRegex finds the #tags, which shlex would take for comments.
+	quickHashRegex = regexp.MustCompile(`(^|\s)#`)
+)
+
+// ParseQuickAdd turns a natural-language add line such as
+//
+//	Submit report next friday 3pm !high #work @office every month
+//
+// into `task add` arguments: "due:2026-10-23T15:00:00", "priority:H",
+// "+work", "project:office" and "recur:monthly" after the remaining words.
+// It understands today, tomorrow, weekdays, "next week|month|year",
+// "in N days|weeks|months|years" and ISO dates, optionally followed by a
+// time (3pm, 15:30), "every" recurrences, !high/!medium/!low (or !!!, !!, !),
+// #tag and @project. An @ reference containing a dot, slash or ~ is a file
+// (e.g. @main.go, opened with "o") and stays in the description, so a
+// subproject needs project:a.b. Words it does not understand, including Taskwarrior
+// modifiers, are passed on unchanged; changed reports whether any phrase was
+// recognized. A recurrence without a date is due on its first occurrence.
+func ParseQuickAdd(line string, now time.Time) (args []string, changed bool, err error) {
+	fields, err := shlex.Split(quickHashRegex.ReplaceAllString(line, `$1\#`))
+	if err != nil {
+		return nil, false, err
+	}
+	var mods []string
+	var due time.Time
+	var recur string
+	var recurDay *time.Weekday
+	for i := 0; i < len(fields); i++ {
+		word := strings.ToLower(fields[i])
+		if p, ok := quickPriorities[word]; ok {
+			mods = append(mods, "priority:"+p)
+			continue
+		}
+		if tag, ok := strings.CutPrefix(fields[i], "#"); ok && tag != "" && !strings.ContainsAny(tag, " :") {
+			mods = append(mods, "+"+tag)
+			continue
+		}
+		if project, ok := strings.CutPrefix(fields[i], "@"); ok && project != "" && !strings.ContainsAny(project, "./~") {
+			mods = append(mods, "project:"+project)
+			continue
+		}
+		if word == "every" && recur == "" {
+			if r, day, n := parseQuickEvery(fields[i+1:]); n > 0 {
+				recur, recurDay = r, day
+				i += n
+				continue
+			}
+		}
+		if due.IsZero() {
+			if d, n := parseQuickDue(fields[i:], now); n > 0 {
+				due = d
+				mods = append(mods, "due:"+formatQuickDue(d))
+				i += n - 1
+				continue
+			}
+		}
+		args = append(args, fields[i])
+	}
+	if recur != "" {
+		if due.IsZero() {
+			due = startOfDay(now)
+			if recurDay != nil {
+				due = nextWeekday(now, *recurDay)
+			}
+			mods = append(mods, "due:"+formatQuickDue(due))
+		}
+		mods = append(mods, "recur:"+recur)
+	}
+	return append(args, mods...), len(mods) > 0, nil
+}
+
+// parseQuickEvery parses the words after "every" and returns the
+// recurrence, the weekday it falls on, if any, and the number of words used.
+func parseQuickEvery(words []string) (string, *time.Weekday, int) {
+	if len(words) == 0 {
+		return "", nil, 0
+	}
+	word := strings.ToLower(words[0])
+	if r, ok := quickEvery[word]; ok {
+		return r, nil, 1
+	}
+	if day, ok := parseWeekday(word); ok {
+		return "weekly", &day, 1
+	}
+	if len(words) < 2 {
+		return "", nil, 0
+	}
+	unit, ok := quickUnits[strings.ToLower(words[1])]
+	if !ok {
+		return "", nil, 0
+	}
+	if word == "other" {
+		return "2" + unit, nil, 2
+	}
+	if n, err := strconv.Atoi(word); err == nil && n > 0 {
+		return fmt.Sprintf("%d%s", n, unit), nil, 2
+	}
+	return "", nil, 0
+}
+
+// parseQuickDue parses a date with an optional time at the start of words,
+// optionally introduced by "due", "by" or "on", and returns it with the
+// number of words used.
+func parseQuickDue(words []string, now time.Time) (time.Time, int) {
+	skip := 0
+	if len(words) > 1 {
+		switch strings.ToLower(words[0]) {
+		case "due", "by", "on":
+			skip = 1
+		}
+	}
+	date, n := parseQuickDate(words[skip:], now)
+	if n == 0 {
+		// A bare time is today at that time.
+		if h, m, ok := parseQuickTime(words[skip:]); ok > 0 {
+			return time.Date(now.Year(), now.Month(), now.Day(), h, m, 0, 0, now.Location()), skip + ok
+		}
+		return time.Time{}, 0
+	}
+	if h, m, ok := parseQuickTime(words[skip+n:]); ok > 0 {
+		return date.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute), skip + n + ok
+	}
+	return date, skip + n
+}
+
+func parseQuickDate(words []string, now time.Time) (time.Time, int) {
+	if len(words) == 0 {
+		return time.Time{}, 0
+	}
+	today := startOfDay(now)
+	word := strings.ToLower(words[0])
+	switch word {
+	case "today":
+		return today, 1
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), 1
+	}
+	if day, ok := parseWeekday(word); ok {
+		return nextWeekday(now, day), 1
+	}
+	if quickDateRegex.MatchString(word) {
+		if d, err := time.ParseInLocation("2006-01-02", word, now.Location()); err == nil {
+			return d, 1
+		}
+	}
+	if len(words) < 2 {
+		return time.Time{}, 0
+	}
+	next := strings.ToLower(words[1])
+	switch word {
+	case "next":
+		if day, ok := parseWeekday(next); ok {
+			return nextWeekday(now, day), 2
+		}
+		switch next {
+		case "week":
+			return nextWeekday(now, time.Monday), 2
+		case "month":
+			return time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location()), 2
+		case "year":
+			return time.Date(now.Year()+1, 1, 1, 0, 0, 0, 0, now.Location()), 2
+		}
+	case "in":
+		if len(words) < 3 {
+			return time.Time{}, 0
+		}
+		n, err := strconv.Atoi(next)
+		if next == "a" || next == "an" {
+			n, err = 1, nil
+		}
+		if err != nil || n <= 0 {
+			return time.Time{}, 0
+		}
+		switch quickUnits[strings.ToLower(words[2])] {
+		case "days":
+			return today.AddDate(0, 0, n), 3
+		case "weeks":
+			return today.AddDate(0, 0, 7*n), 3
+		case "months":
+			return today.AddDate(0, n, 0), 3
+		case "years":
+			return today.AddDate(n, 0, 0), 3
+		}
+	}
+	return time.Time{}, 0
+}
+
+// parseQuickTime parses "3pm", "3:30pm", "15:00" or "noon", optionally
+// after "at", and returns the hour, minute and number of words used.
+func parseQuickTime(words []string) (int, int, int) {
+	skip := 0
+	if len(words) > 1 && strings.ToLower(words[0]) == "at" {
+		skip = 1
+	}
+	if len(words) <= skip {
+		return 0, 0, 0
+	}
+	word := strings.ToLower(words[skip])
+	if word == "noon" {
+		return 12, 0, skip + 1
+	}
+	match := quickTimeRegex.FindStringSubmatch(word)
+	// A bare number is not a time; "15:00" or "3pm" is.
+	if match == nil || (match[2] == "" && match[3] == "") {
+		return 0, 0, 0
+	}
+	h, _ := strconv.Atoi(match[1])
+	m, _ := strconv.Atoi(match[2])
+	switch {
+	case match[3] != "" && (h < 1 || h > 12):
+		return 0, 0, 0
+	case match[3] == "pm" && h < 12:
+		h += 12
+	case match[3] == "am" && h == 12:
+		h = 0
+	}
+	if h > 23 || m > 59 {
+		return 0, 0, 0
+	}
+	return h, m, skip + 1
+}
+
+func parseWeekday(word string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		if word == strings.ToLower(d.String()) {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+// nextWeekday returns the first day after now falling on day, like
+// Taskwarrior's weekday names.
+func nextWeekday(now time.Time, day time.Weekday) time.Time {
+	days := (int(day)-int(now.Weekday())+6)%7 + 1
+	return startOfDay(now).AddDate(0, 0, days)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+func formatQuickDue(t time.Time) string {
+	if t.Hour() == 0 && t.Minute() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02T15:04:05")
+}
@@ -0,0 +1,41 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseQuickAdd(t *testing.T) {
+	now := time.Date(2026, 10, 18, 9, 30, 0, 0, time.Local) // a Sunday
+	tests := []struct {
+		line    string
+		want    []string
+		changed bool
+	}{
+		{
+			"Submit report next friday 3pm !high #work @office every month",
+			[]string{"Submit", "report", "due:2026-10-23T15:00:00", "priority:H", "+work", "project:office", "recur:monthly"},
+			true,
+		},
+		{"Call Bob tomorrow at 9:15am", []string{"Call", "Bob", "due:2026-10-19T09:15:00"}, true},
+		{"Pay rent every 2 weeks", []string{"Pay", "rent", "due:2026-10-18", "recur:2weeks"}, true},
+		{"Gym every monday", []string{"Gym", "due:2026-10-19", "recur:weekly"}, true},
+		{"Renew passport in 3 months !!", []string{"Renew", "passport", "due:2027-01-18", "priority:M"}, true},
+		{"Plan trip due 2026-12-01 noon", []string{"Plan", "trip", "due:2026-12-01T12:00:00"}, true},
+		{"Read @docs/notes.md on sunday", []string{"Read", "@docs/notes.md", "due:2026-10-25"}, true},
+		{"open @main.go today", []string{"open", "@main.go", "due:2026-10-18"}, true},
+		{"Review @notes.md", []string{"Review", "@notes.md"}, false},
+		{"Review +bug project:dev due:eow", []string{"Review", "+bug", "project:dev", "due:eow"}, false},
+		{"Buy 3 apples for the next trip", []string{"Buy", "3", "apples", "for", "the", "next", "trip"}, false},
+	}
+	for _, tt := range tests {
+		got, changed, err := ParseQuickAdd(tt.line, now)
+		if err != nil {
+			t.Fatalf("ParseQuickAdd(%q): %v", tt.line, err)
+		}
+		if !reflect.DeepEqual(got, tt.want) || changed != tt.changed {
+			t.Errorf("ParseQuickAdd(%q) = %q, %v; want %q, %v", tt.line, got, changed, tt.want, tt.changed)
+		}
+	}
+}
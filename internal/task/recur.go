@@ -0,0 +1,116 @@
+package task
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// recurDurations are the fixed-length periods Taskwarrior accepts after an
+// optional count, e.g. "3d" or "2weeks". Calendar periods such as
+// "monthly" are handled by nextRecurrence.
+var recurDurations = map[string]time.Duration{
+	"annual": 365 * day, "biannual": 730 * day, "bimonthly": 61 * day,
+	"biweekly": 14 * day, "biyearly": 730 * day, "daily": day, "day": day,
+	"days": day, "d": day, "fortnight": 14 * day, "hours": time.Hour,
+	"hour": time.Hour, "hrs": time.Hour, "hr": time.Hour, "h": time.Hour,
+	"minutes": time.Minute, "mins": time.Minute, "min": time.Minute,
+	"monthly": 30 * day, "months": 30 * day, "month": 30 * day,
+	"mnths": 30 * day, "mos": 30 * day, "mo": 30 * day,
+	"quarterly": 91 * day, "quarters": 91 * day, "qrtrs": 91 * day,
+	"qtrs": 91 * day, "semiannual": 183 * day, "sennight": 7 * day,
+	"seconds": time.Second, "secs": time.Second, "sec": time.Second,
+	"s": time.Second, "weekly": 7 * day, "weeks": 7 * day, "week": 7 * day,
+	"wks": 7 * day, "wk": 7 * day, "w": 7 * day, "yearly": 365 * day,
+	"years": 365 * day, "year": 365 * day, "yrs": 365 * day,
+	"yr": 365 * day, "y": 365 * day,
+}
+
+const day = 24 * time.Hour
+
+// NextOccurrences returns up to n due dates that follow due in a series
+// recurring by period, the way Taskwarrior generates them: "monthly", "Nm",
+// "quarterly", "Nq", "semiannual", "bimonthly", "annual"/"yearly" and
+// "biannual"/"biyearly" step by calendar months or years, keeping the day
+// of month where it exists; "weekdays" skips weekends; anything else, such
+// as "4weeks" or "1mo", adds a fixed duration. Dates after a non-zero until
+// are left out, so fewer than n dates mean the series ends.
+func NextOccurrences(due time.Time, period string, until time.Time, n int) ([]time.Time, error) {
+	period = strings.ToLower(strings.TrimSpace(period))
+	if period == "" {
+		return nil, fmt.Errorf("empty recurrence")
+	}
+	var dates []time.Time
+	current := due
+	for len(dates) < n {
+		next, err := nextRecurrence(current, period)
+		if err != nil {
+			return nil, err
+		}
+		if !until.IsZero() && next.After(until) {
+			break
+		}
+		dates = append(dates, next)
+		current = next
+	}
+	return dates, nil
+}
+
+// nextRecurrence mirrors getNextRecurrence of Taskwarrior's recur.cpp.
+func nextRecurrence(current time.Time, period string) (time.Time, error) {
+	count, unit := splitRecurCount(strings.TrimPrefix(period, "="))
+	switch {
+	case unit == "monthly" && count == 0:
+		return addMonths(current, 1), nil
+	case unit == "m" && count > 0:
+		return addMonths(current, count), nil
+	case unit == "quarterly" && count == 0:
+		return addMonths(current, 3), nil
+	case unit == "q" && count > 0:
+		return addMonths(current, 3*count), nil
+	case unit == "semiannual" && count == 0:
+		return addMonths(current, 6), nil
+	case unit == "bimonthly" && count == 0:
+		return addMonths(current, 2), nil
+	case (unit == "biannual" || unit == "biyearly") && count == 0:
+		return addMonths(current, 24), nil
+	case (unit == "annual" || unit == "yearly") && count == 0:
+		return addMonths(current, 12), nil
+	case unit == "weekdays" && count == 0:
+		next := current.AddDate(0, 0, 1)
+		for next.Weekday() == time.Saturday || next.Weekday() == time.Sunday {
+			next = next.AddDate(0, 0, 1)
+		}
+		return next, nil
+	}
+	d, ok := recurDurations[unit]
+	if !ok {
+		return time.Time{}, fmt.Errorf("unknown recurrence %q", period)
+	}
+	if count == 0 {
+		count = 1
+	}
+	return current.Add(time.Duration(count) * d), nil
+}
+
+// splitRecurCount splits "3weeks" into 3 and "weeks"; the count is 0 when
+// the period has no leading number.
+func splitRecurCount(period string) (int, string) {
+	i := strings.IndexFunc(period, func(r rune) bool { return !unicode.IsDigit(r) })
+	if i <= 0 {
+		return 0, period
+	}
+	n, _ := strconv.Atoi(period[:i])
+	return n, period[i:]
+}
+
+// addMonths adds calendar months, moving to the last day of the month when
+// the day does not exist there (Jan 31 + 1 month = Feb 28).
+func addMonths(t time.Time, months int) time.Time {
+	y, m, d := t.Date()
+	first := time.Date(y, m+time.Month(months), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	return first.AddDate(0, 0, min(d, last)-1)
+}
@@ -0,0 +1,48 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextOccurrences(t *testing.T) {
+	due := time.Date(2026, 1, 31, 9, 0, 0, 0, time.UTC) // a Saturday
+	dates := func(ds ...string) []string { return ds }
+	tests := []struct {
+		period string
+		until  time.Time
+		want   []string
+	}{
+		{"monthly", time.Time{}, dates("2026-02-28", "2026-03-28", "2026-04-28")},
+		{"4weeks", time.Time{}, dates("2026-02-28", "2026-03-28", "2026-04-25")},
+		{"1mo", time.Time{}, dates("2026-03-02", "2026-04-01", "2026-05-01")},
+		{"2m", time.Time{}, dates("2026-03-31", "2026-05-31", "2026-07-31")},
+		{"quarterly", time.Time{}, dates("2026-04-30", "2026-07-30", "2026-10-30")},
+		{"weekdays", time.Time{}, dates("2026-02-02", "2026-02-03", "2026-02-04")},
+		{"yearly", time.Time{}, dates("2027-01-31", "2028-01-31", "2029-01-31")},
+		{"daily", time.Date(2026, 2, 2, 9, 0, 0, 0, time.UTC), dates("2026-02-01", "2026-02-02")},
+	}
+	for _, tt := range tests {
+		got, err := NextOccurrences(due, tt.period, tt.until, 3)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.period, err)
+		}
+		var gotDays []string
+		for _, d := range got {
+			gotDays = append(gotDays, d.Format("2006-01-02"))
+		}
+		if len(gotDays) != len(tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.period, gotDays, tt.want)
+			continue
+		}
+		for i := range gotDays {
+			if gotDays[i] != tt.want[i] {
+				t.Errorf("%s: got %v, want %v", tt.period, gotDays, tt.want)
+				break
+			}
+		}
+	}
+	if _, err := NextOccurrences(due, "fortnightly-ish", time.Time{}, 3); err == nil {
+		t.Errorf("expected an error for an unknown period")
+	}
+}
@@ -0,0 +1,194 @@
+package task
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/google/shlex"
+)
+
+// RewriteRule is a parsed tag and project rewrite rule. It is written as
+// conditions and modifications separated by "->":
+//
+//	tag:inbox + desc~"^PR " -> project:review -tag:inbox
+//
+// Conditions are tag:NAME (or +NAME), project:NAME (matching subprojects
+// too, an empty name matches tasks without project), priority:H and
+// desc~REGEX, each negated by a leading "-"; a task must match all of them.
+// Modifications are +NAME or tag:NAME, -NAME or -tag:NAME, project:NAME and
+// priority:P.
+type RewriteRule struct {
+	conds   []ruleCond
+	actions []ruleAction
+}
+
+type ruleCond struct {
+	field  string // "tag", "project", "priority" or "desc"
+	value  string
+	re     *regexp.Regexp
+	negate bool
+}
+
+type ruleAction struct {
+	field  string // "tag", "project" or "priority"
+	value  string
+	remove bool // remove the tag
+}
+
+// ParseRewriteRule parses a rule written as described for RewriteRule.
+func ParseRewriteRule(text string) (RewriteRule, error) {
+	fields, err := shlex.Split(text)
+	if err != nil {
+		return RewriteRule{}, fmt.Errorf("rule %q: %w", text, err)
+	}
+	arrow := slices.Index(fields, "->")
+	if arrow < 0 || slices.Contains(fields[arrow+1:], "->") {
+		return RewriteRule{}, fmt.Errorf("rule %q: want one \"->\" between conditions and modifications", text)
+	}
+	var r RewriteRule
+	for _, field := range fields[:arrow] {
+		if field == "+" || strings.EqualFold(field, "and") {
+			continue
+		}
+		c, err := parseRuleCond(field)
+		if err != nil {
+			return RewriteRule{}, fmt.Errorf("rule %q: %w", text, err)
+		}
+		r.conds = append(r.conds, c)
+	}
+	for _, field := range fields[arrow+1:] {
+		a, err := parseRuleAction(field)
+		if err != nil {
+			return RewriteRule{}, fmt.Errorf("rule %q: %w", text, err)
+		}
+		r.actions = append(r.actions, a)
+	}
+	switch {
+	case len(r.conds) == 0:
+		return RewriteRule{}, fmt.Errorf("rule %q has no conditions", text)
+	case len(r.actions) == 0:
+		return RewriteRule{}, fmt.Errorf("rule %q has no modifications", text)
+	}
+	return r, nil
+}
+
+func parseRuleCond(field string) (ruleCond, error) {
+	var c ruleCond
+	if rest, ok := strings.CutPrefix(field, "-"); ok {
+		c.negate = true
+		field = rest
+	}
+	if name, ok := strings.CutPrefix(field, "+"); ok {
+		field = "tag:" + name
+	} else if c.negate && !strings.ContainsAny(field, ":~") {
+		field = "tag:" + field
+	}
+	for _, prefix := range []string{"desc~", "description~"} {
+		if expr, ok := strings.CutPrefix(field, prefix); ok {
+			re, err := regexp.Compile(expr)
+			if err != nil {
+				return c, fmt.Errorf("condition %s: %w", field, err)
+			}
+			c.field, c.re = "desc", re
+			return c, nil
+		}
+	}
+	key, value, ok := strings.Cut(field, ":")
+	c.field, c.value = ruleField(key), value
+	switch {
+	case !ok || c.field == "":
+		return c, fmt.Errorf("unknown condition %q", field)
+	case c.field == "tag" && value == "":
+		return c, fmt.Errorf("condition %q has no tag", field)
+	}
+	return c, nil
+}
+
+func parseRuleAction(field string) (ruleAction, error) {
+	var a ruleAction
+	switch {
+	case strings.HasPrefix(field, "-"):
+		a.remove = true
+		field = strings.TrimPrefix(strings.TrimPrefix(field, "-"), "tag:")
+		a.field, a.value = "tag", field
+	case strings.HasPrefix(field, "+"):
+		a.field, a.value = "tag", strings.TrimPrefix(field, "+")
+	default:
+		key, value, ok := strings.Cut(field, ":")
+		if a.field, a.value = ruleField(key), value; !ok || a.field == "" {
+			return a, fmt.Errorf("unknown modification %q", field)
+		}
+	}
+	switch {
+	case a.field == "tag" && (a.value == "" || strings.Contains(a.value, ":")):
+		return a, fmt.Errorf("modification %q: want +TAG or -TAG", field)
+	case a.field == "priority" && !slices.Contains([]string{"", "H", "M", "L"}, a.value):
+		return a, fmt.Errorf("modification %q: priority must be H, M, L or empty", field)
+	}
+	return a, nil
+}
+
+// ruleField maps the attribute names and abbreviations of a rule to the
+// fields it supports.
+func ruleField(key string) string {
+	switch key {
+	case "tag", "tags":
+		return "tag"
+	case "project", "proj", "pro":
+		return "project"
+	case "priority", "pri":
+		return "priority"
+	}
+	return ""
+}
+
+// Matches reports whether t matches all conditions of the rule.
+func (r RewriteRule) Matches(t Task) bool {
+	for _, c := range r.conds {
+		var ok bool
+		switch c.field {
+		case "tag":
+			ok = slices.Contains(t.Tags, c.value)
+		case "project":
+			ok = t.Project == c.value || (c.value != "" && strings.HasPrefix(t.Project, c.value+"."))
+		case "priority":
+			ok = t.Priority == c.value
+		case "desc":
+			ok = c.re.MatchString(t.Description)
+		}
+		if ok == c.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// Apply returns t as the rule's modifications leave it and the modifier
+// arguments that get there, without the ones that change nothing. A task
+// the rule does not match is returned unchanged.
+func (r RewriteRule) Apply(t Task) (Task, []string) {
+	if !r.Matches(t) {
+		return t, nil
+	}
+	t.Tags = slices.Clone(t.Tags)
+	var args []string
+	for _, a := range r.actions {
+		switch {
+		case a.field == "project" && t.Project != a.value:
+			t.Project = a.value
+			args = append(args, "project:"+a.value)
+		case a.field == "priority" && t.Priority != a.value:
+			t.Priority = a.value
+			args = append(args, "priority:"+a.value)
+		case a.field == "tag" && a.remove && slices.Contains(t.Tags, a.value):
+			t.Tags = slices.DeleteFunc(t.Tags, func(tag string) bool { return tag == a.value })
+			args = append(args, "-"+a.value)
+		case a.field == "tag" && !a.remove && !slices.Contains(t.Tags, a.value):
+			t.Tags = append(t.Tags, a.value)
+			args = append(args, "+"+a.value)
+		}
+	}
+	return t, args
+}
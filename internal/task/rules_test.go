@@ -0,0 +1,53 @@
+package task
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRewriteRule(t *testing.T) {
+	rule, err := ParseRewriteRule(`tag:inbox + desc~"^PR " -> project:review -tag:inbox +code`)
+	if err != nil {
+		t.Fatalf("ParseRewriteRule: %v", err)
+	}
+	tests := []struct {
+		task Task
+		args []string
+	}{
+		{Task{Description: "PR 12: fix login", Tags: []string{"inbox"}}, []string{"project:review", "-inbox", "+code"}},
+		{Task{Description: "PR 13", Project: "review", Tags: []string{"inbox", "code"}}, []string{"-inbox"}},
+		{Task{Description: "Read PR 14", Tags: []string{"inbox"}}, nil},
+		{Task{Description: "PR 15"}, nil},
+	}
+	for _, tt := range tests {
+		got, args := rule.Apply(tt.task)
+		if !reflect.DeepEqual(args, tt.args) {
+			t.Errorf("Apply(%q) args = %q, want %q", tt.task.Description, args, tt.args)
+		}
+		if args != nil && (got.Project != "review" || !reflect.DeepEqual(got.Tags, []string{"code"})) {
+			t.Errorf("Apply(%q) = %+v", tt.task.Description, got)
+		}
+	}
+
+	negated, err := ParseRewriteRule("-work project:home -> pri:L")
+	if err != nil {
+		t.Fatalf("ParseRewriteRule: %v", err)
+	}
+	if !negated.Matches(Task{Project: "home.garden"}) || negated.Matches(Task{Project: "home", Tags: []string{"work"}}) {
+		t.Error("unexpected matches of the negated rule")
+	}
+
+	for _, bad := range []string{
+		"tag:inbox",
+		"-> +x",
+		"tag:inbox ->",
+		"due:today -> +x",
+		"tag:a -> pri:X",
+		"tag:a -> +b -> +c",
+		`desc~"(" -> +x`,
+	} {
+		if _, err := ParseRewriteRule(bad); err == nil {
+			t.Errorf("ParseRewriteRule(%q) succeeded", bad)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Series is a recurring template together with the instances Taskwarrior
+// generated from it.
+type Series struct {
+	Template  Task
+	Instances []Task
+}
+
+// GroupSeries pairs every recurring template in tasks with its instances,
+// ordered by template description. Instances whose template is missing are
+// dropped.
+func GroupSeries(tasks []Task) []Series {
+	index := make(map[string]int)
+	var series []Series
+	for _, t := range tasks {
+		if t.Status == "recurring" && t.Parent == "" {
+			if _, ok := index[t.UUID]; !ok {
+				index[t.UUID] = len(series)
+				series = append(series, Series{Template: t})
+			}
+		}
+	}
+	seen := make(map[string]bool)
+	for _, t := range tasks {
+		i, ok := index[t.Parent]
+		if !ok || seen[t.UUID] {
+			continue
+		}
+		seen[t.UUID] = true
+		series[i].Instances = append(series[i].Instances, t)
+	}
+	sort.SliceStable(series, func(i, j int) bool {
+		return series[i].Template.Description < series[j].Template.Description
+	})
+	return series
+}
+
+// Count returns the number of instances with the given status. "pending"
+// includes waiting instances.
+func (s Series) Count(status string) int {
+	n := 0
+	for _, t := range s.Instances {
+		if t.Status == status || status == "pending" && t.Status == "waiting" {
+			n++
+		}
+	}
+	return n
+}
+
+// NextDue returns the earliest due date of the pending instances, in
+// DateFormat, or "" when nothing is pending.
+func (s Series) NextDue() string {
+	next := ""
+	for _, t := range s.Instances {
+		if (t.Status == "pending" || t.Status == "waiting") && t.Due != "" && (next == "" || t.Due < next) {
+			next = t.Due
+		}
+	}
+	return next
+}
+
+// CompletionRate returns the share of finished instances that were
+// completed rather than deleted; ok is false while none are finished.
+func (s Series) CompletionRate() (rate float64, ok bool) {
+	done, deleted := s.Count("completed"), s.Count("deleted")
+	if done+deleted == 0 {
+		return 0, false
+	}
+	return float64(done) / float64(done+deleted), true
+}
+
+// Paused reports whether the series is paused, i.e. its template waits
+// until after now (see ModifySeriesContext with "wait:someday").
+func (s Series) Paused(now time.Time) bool {
+	return dateAfter(s.Template.Wait, now)
+}
+
+// ModifySeriesContext applies modifier arguments to a recurring template
+// and its pending instances, one task at a time so Taskwarrior never asks
+// whether to change the other recurrences. Completed and deleted instances
+// keep their values.
+func ModifySeriesContext(ctx context.Context, rootUUID string, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("no modifications given")
+	}
+	tasks, err := RecurringSeries(ctx, rootUUID)
+	if err != nil {
+		return err
+	}
+	tasks = recurringSeriesUpdateOrder(tasks, rootUUID)
+	if len(tasks) == 0 {
+		return fmt.Errorf("recurring series %s not found", rootUUID)
+	}
+	for _, t := range tasks {
+		if t.UUID != rootUUID && t.Status != "pending" && t.Status != "waiting" {
+			continue
+		}
+		cmd := append([]string{"rc.recurrence.confirmation=no", t.UUID, "modify"}, args...)
+		if err := runContext(ctx, cmd...); err != nil {
+			return fmt.Errorf("modify %s: %w", t.UUID, err)
+		}
+	}
+	return nil
+}
+
+// HabitState is what happened to a series on one day.
+type HabitState int
+
+const (
+	HabitNone    HabitState = iota // no instance due that day
+	HabitPending                   // due today or later, not done yet
+	HabitDeleted                   // the instance was deleted
+	HabitMissed                    // due on an earlier day and still pending
+	HabitDone                      // the instance was completed
+)
+
+// habitDay is the local calendar day of a Taskwarrior date, as YYYY-MM-DD.
+func habitDay(date string) (string, bool) {
+	ts, err := time.Parse(DateFormat, date)
+	if err != nil {
+		return "", false
+	}
+	return ts.Local().Format(time.DateOnly), true
+}
+
+// instanceState classifies an instance relative to today (YYYY-MM-DD).
+func instanceState(t Task, day, today string) HabitState {
+	switch t.Status {
+	case "completed":
+		return HabitDone
+	case "deleted":
+		return HabitDeleted
+	}
+	if day < today {
+		return HabitMissed
+	}
+	return HabitPending
+}
+
+// HabitDays returns the state of the series per local due day, keyed by
+// YYYY-MM-DD. When several instances share a day the most significant state
+// wins (done over missed over deleted).
+func (s Series) HabitDays(now time.Time) map[string]HabitState {
+	today := now.Local().Format(time.DateOnly)
+	days := make(map[string]HabitState)
+	for _, t := range s.Instances {
+		day, ok := habitDay(t.Due)
+		if !ok {
+			continue
+		}
+		if state := instanceState(t, day, today); state > days[day] {
+			days[day] = state
+		}
+	}
+	return days
+}
+
+// Streaks returns the number of consecutive completed instances up to the
+// most recent one due before today, and the longest such run. Deleted and
+// missed instances break a streak; instances due today or later are not
+// counted yet.
+func (s Series) Streaks(now time.Time) (current, longest int) {
+	type instance struct {
+		day   string
+		state HabitState
+	}
+	today := now.Local().Format(time.DateOnly)
+	var instances []instance
+	for _, t := range s.Instances {
+		day, ok := habitDay(t.Due)
+		if !ok {
+			continue
+		}
+		if state := instanceState(t, day, today); state != HabitPending {
+			instances = append(instances, instance{day, state})
+		}
+	}
+	sort.SliceStable(instances, func(i, j int) bool { return instances[i].day < instances[j].day })
+	for _, in := range instances {
+		if in.state == HabitDone {
+			current++
+			longest = max(longest, current)
+		} else {
+			current = 0
+		}
+	}
+	return current, longest
+}
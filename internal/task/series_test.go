@@ -0,0 +1,67 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func TestGroupSeries(t *testing.T) {
+	tasks := []Task{
+		{UUID: "w", Description: "Water plants", Status: "recurring", Recur: "weekly", Wait: "99991230T000000Z"},
+		{UUID: "r", Description: "Review inbox", Status: "recurring", Recur: "daily"},
+		{UUID: "r1", Parent: "r", Status: "completed", Due: "20261015T000000Z"},
+		{UUID: "r2", Parent: "r", Status: "deleted", Due: "20261016T000000Z"},
+		{UUID: "r3", Parent: "r", Status: "completed", Due: "20261017T000000Z"},
+		{UUID: "r5", Parent: "r", Status: "pending", Due: "20261019T000000Z"},
+		{UUID: "r4", Parent: "r", Status: "waiting", Due: "20261018T000000Z"},
+		{UUID: "r4", Parent: "r", Status: "waiting", Due: "20261018T000000Z"},
+		{UUID: "x1", Parent: "gone", Status: "pending"},
+		{UUID: "p", Description: "Plain", Status: "pending"},
+	}
+	series := GroupSeries(tasks)
+	if len(series) != 2 || series[0].Template.UUID != "r" || series[1].Template.UUID != "w" {
+		t.Fatalf("series = %+v", series)
+	}
+	r := series[0]
+	if len(r.Instances) != 5 || r.Count("pending") != 2 || r.Count("completed") != 2 {
+		t.Fatalf("instances = %+v", r.Instances)
+	}
+	if r.NextDue() != "20261018T000000Z" {
+		t.Fatalf("NextDue = %q", r.NextDue())
+	}
+	if rate, ok := r.CompletionRate(); !ok || rate < 0.66 || rate > 0.67 {
+		t.Fatalf("CompletionRate = %v, %v", rate, ok)
+	}
+	if _, ok := series[1].CompletionRate(); ok {
+		t.Fatalf("expected no rate without finished instances")
+	}
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+	if r.Paused(now) || !series[1].Paused(now) {
+		t.Fatalf("unexpected paused state")
+	}
+}
+
+func TestSeriesHabits(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local)
+	due := func(day int) string {
+		return time.Date(2026, 10, day, 9, 0, 0, 0, time.Local).UTC().Format(DateFormat)
+	}
+	s := Series{Template: Task{UUID: "r", Status: "recurring"}, Instances: []Task{
+		{Status: "completed", Due: due(11)},
+		{Status: "completed", Due: due(12)},
+		{Status: "pending", Due: due(13)},
+		{Status: "completed", Due: due(14)},
+		{Status: "completed", Due: due(15)},
+		{Status: "completed", Due: due(16)},
+		{Status: "deleted", Due: due(17)},
+		{Status: "completed", Due: due(17)},
+		{Status: "pending", Due: due(18)},
+	}}
+	days := s.HabitDays(now)
+	if days["2026-10-13"] != HabitMissed || days["2026-10-17"] != HabitDone || days["2026-10-18"] != HabitPending || days["2026-10-10"] != HabitNone {
+		t.Fatalf("days = %v", days)
+	}
+	if current, longest := s.Streaks(now); current != 1 || longest != 3 {
+		t.Fatalf("streaks = %d, %d; want 1, 3", current, longest)
+	}
+}
@@ -38,3 +38,73 @@ func DueTasks(tasks []Task, now time.Time) int {
 	}
 	return count
 }
+
+// WeekStart returns local midnight of the Monday of the week containing ts.
+func WeekStart(ts time.Time) time.Time {
+	ts = ts.Local()
+	day := time.Date(ts.Year(), ts.Month(), ts.Day(), 0, 0, 0, 0, time.Local)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+// CompletionsPerDay counts completed tasks by the local day of their end
+// date, keyed by YYYY-MM-DD.
+func CompletionsPerDay(tasks []Task) map[string]int {
+	days := make(map[string]int)
+	for _, t := range tasks {
+		if t.Status != "completed" {
+			continue
+		}
+		if ts, err := time.Parse(DateFormat, t.End); err == nil {
+			days[ts.Local().Format(time.DateOnly)]++
+		}
+	}
+	return days
+}
+
+// WeekTrend counts the tasks created and completed in one week.
+type WeekTrend struct {
+	Start     time.Time // local midnight of the Monday
+	Created   int
+	Completed int
+}
+
+// WeeklyTrend returns the number of tasks created and completed in each of
+// the last weeks up to the one containing now, oldest first. Recurring
+// templates are not counted; their instances are.
+func WeeklyTrend(tasks []Task, weeks int, now time.Time) []WeekTrend {
+	if weeks <= 0 {
+		return nil
+	}
+	first := WeekStart(now).AddDate(0, 0, -7*(weeks-1))
+	trend := make([]WeekTrend, weeks)
+	for i := range trend {
+		trend[i].Start = first.AddDate(0, 0, 7*i)
+	}
+	week := func(date string) int {
+		ts, err := time.Parse(DateFormat, date)
+		if err != nil || ts.Before(first) {
+			return -1
+		}
+		for i := len(trend) - 1; i >= 0; i-- {
+			if !ts.Before(trend[i].Start) {
+				return i
+			}
+		}
+		return -1
+	}
+	for _, t := range tasks {
+		if t.Status == "recurring" {
+			continue
+		}
+		if i := week(t.Entry); i >= 0 {
+			trend[i].Created++
+		}
+		if t.Status != "completed" {
+			continue
+		}
+		if i := week(t.End); i >= 0 {
+			trend[i].Completed++
+		}
+	}
+	return trend
+}
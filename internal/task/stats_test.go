@@ -26,3 +26,31 @@ func TestStats(t *testing.T) {
 		t.Errorf("due tasks wrong: %d", DueTasks(tasks, now))
 	}
 }
+
+func TestCompletionTrends(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local) // a Sunday
+	at := func(month time.Month, day int) string {
+		return time.Date(2026, month, day, 10, 0, 0, 0, time.Local).UTC().Format(DateFormat)
+	}
+	tasks := []Task{
+		{Status: "completed", Entry: at(10, 1), End: at(10, 12)},
+		{Status: "completed", Entry: at(10, 13), End: at(10, 13)},
+		{Status: "completed", Entry: at(10, 13), End: at(10, 13)},
+		{Status: "pending", Entry: at(10, 6)},
+		{Status: "deleted", Entry: at(10, 7), End: at(10, 8)},
+		{Status: "recurring", Entry: at(10, 13)},
+	}
+
+	days := CompletionsPerDay(tasks)
+	if len(days) != 2 || days["2026-10-12"] != 1 || days["2026-10-13"] != 2 {
+		t.Fatalf("completions per day = %v", days)
+	}
+
+	trend := WeeklyTrend(tasks, 2, now)
+	if len(trend) != 2 || !trend[0].Start.Equal(time.Date(2026, 10, 5, 0, 0, 0, 0, time.Local)) {
+		t.Fatalf("unexpected weeks %+v", trend)
+	}
+	if trend[0].Created != 2 || trend[0].Completed != 0 || trend[1].Created != 2 || trend[1].Completed != 3 {
+		t.Fatalf("trend = %+v", trend)
+	}
+}
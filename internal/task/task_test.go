@@ -192,6 +192,36 @@ func TestRunShellLineDisablesRecurrencePrompt(t *testing.T) {
 	}
 }
 
+func TestModifyUUIDDisablesRecurrencePrompt(t *testing.T) {
+	tmp := t.TempDir()
+	taskPath := filepath.Join(tmp, "task")
+	argsFile := filepath.Join(tmp, "args.txt")
+
+	script := "#!/bin/sh\n" +
+		"printf '%s\\n' \"$@\" > " + argsFile + "\n"
+	if err := os.WriteFile(taskPath, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	origPath := os.Getenv("PATH")
+	_ = os.Setenv("PATH", tmp+":"+origPath)
+	t.Cleanup(func() { _ = os.Setenv("PATH", origPath) })
+
+	if err := ModifyUUIDContext(context.Background(), "tmpl", []string{"project:home"}); err != nil {
+		t.Fatalf("ModifyUUIDContext: %v", err)
+	}
+
+	data, err := os.ReadFile(argsFile)
+	if err != nil {
+		t.Fatalf("read args: %v", err)
+	}
+	got := strings.Split(strings.TrimSpace(string(data)), "\n")
+	want := []string{"rc.recurrence.confirmation=no", "tmpl", "modify", "project:home"}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("args = %#v, want %#v", got, want)
+	}
+}
+
 func TestRunLineReturnsCapturedErrorOutput(t *testing.T) {
 	tmp := t.TempDir()
 	taskPath := filepath.Join(tmp, "task")
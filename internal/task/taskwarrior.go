@@ -17,6 +17,7 @@ type Taskwarrior interface {
 	RunShellLine(ctx context.Context, line string) (RunResult, error)
 	LoadCompletionSources(ctx context.Context) CompletionSources
 	AddLineContext(ctx context.Context, line string) error
+	AddArgsContext(ctx context.Context, args []string) error
 	AnnotateContext(ctx context.Context, id int, text string) error
 	ReplaceAnnotations(ctx context.Context, id int, text string) error
 	SetDescriptionContext(ctx context.Context, id int, desc string) error
@@ -25,12 +26,19 @@ type Taskwarrior interface {
 	SetDueDateContext(ctx context.Context, id int, due string) error
 	SetRecurrenceContext(ctx context.Context, id int, rec string) error
 	SetRecurringSeriesRecurrenceContext(ctx context.Context, rootUUID, rec string) error
+	ModifySeriesContext(ctx context.Context, rootUUID string, args []string) error
 	SetProjectContext(ctx context.Context, id int, project string) error
+	AddDependencyContext(ctx context.Context, id int, uuid string) error
 	SetPriorityContext(ctx context.Context, id int, priority string) error
 	StartContext(ctx context.Context, id int) error
 	StopContext(ctx context.Context, id int) error
 	DoneContext(ctx context.Context, id int) error
 	SetStatusUUIDContext(ctx context.Context, uuid, status string) error
+	ModifyUUIDContext(ctx context.Context, uuid string, args []string) error
+	ImportContext(ctx context.Context, tasks []Task) error
+	History(ctx context.Context, uuid string) ([]HistoryEntry, error)
+	LoadUrgencyConfig(ctx context.Context) (UrgencyConfig, error)
+	SetConfigContext(ctx context.Context, name, value string) error
 	RecurringSeries(ctx context.Context, rootUUID string) ([]Task, error)
 }
 
@@ -89,6 +97,11 @@ func (Client) AddLineContext(ctx context.Context, line string) error {
 	return AddLineContext(ctx, line)
 }
 
+// AddArgsContext adds a task from already separated "task add" arguments.
+func (Client) AddArgsContext(ctx context.Context, args []string) error {
+	return AddArgsContext(ctx, args)
+}
+
 // AnnotateContext adds an annotation to a task.
 func (Client) AnnotateContext(ctx context.Context, id int, text string) error {
 	return AnnotateContext(ctx, id, text)
@@ -129,11 +142,22 @@ func (Client) SetRecurringSeriesRecurrenceContext(ctx context.Context, rootUUID,
 	return SetRecurringSeriesRecurrenceContext(ctx, rootUUID, rec)
 }
 
+// ModifySeriesContext applies modifier arguments to a recurring template and
+// its pending instances.
+func (Client) ModifySeriesContext(ctx context.Context, rootUUID string, args []string) error {
+	return ModifySeriesContext(ctx, rootUUID, args)
+}
+
 // SetProjectContext changes a task project.
 func (Client) SetProjectContext(ctx context.Context, id int, project string) error {
 	return SetProjectContext(ctx, id, project)
 }
 
+// AddDependencyContext adds a dependency on the task with the given UUID.
+func (Client) AddDependencyContext(ctx context.Context, id int, uuid string) error {
+	return AddDependencyContext(ctx, id, uuid)
+}
+
 // SetPriorityContext changes a task priority.
 func (Client) SetPriorityContext(ctx context.Context, id int, priority string) error {
 	return SetPriorityContext(ctx, id, priority)
@@ -159,6 +183,31 @@ func (Client) SetStatusUUIDContext(ctx context.Context, uuid, status string) err
 	return SetStatusUUIDContext(ctx, uuid, status)
 }
 
+// ModifyUUIDContext applies modifier arguments to the task with the given UUID.
+func (Client) ModifyUUIDContext(ctx context.Context, uuid string, args []string) error {
+	return ModifyUUIDContext(ctx, uuid, args)
+}
+
+// ImportContext creates tasks through "task import".
+func (Client) ImportContext(ctx context.Context, tasks []Task) error {
+	return ImportContext(ctx, tasks)
+}
+
+// History returns the modification journal of a task.
+func (Client) History(ctx context.Context, uuid string) ([]HistoryEntry, error) {
+	return History(ctx, uuid)
+}
+
+// LoadUrgencyConfig returns the urgency coefficients from the Taskwarrior config.
+func (Client) LoadUrgencyConfig(ctx context.Context) (UrgencyConfig, error) {
+	return LoadUrgencyConfig(ctx)
+}
+
+// SetConfigContext writes a setting to .taskrc.
+func (Client) SetConfigContext(ctx context.Context, name, value string) error {
+	return SetConfigContext(ctx, name, value)
+}
+
 // RecurringSeries returns a recurring task series.
 func (Client) RecurringSeries(ctx context.Context, rootUUID string) ([]Task, error) {
 	return RecurringSeries(ctx, rootUUID)
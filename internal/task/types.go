@@ -1,5 +1,11 @@
 package task
 
+import (
+	"encoding/json"
+	"strings"
+	"time"
+)
+
 // DateFormat is the date format used by Taskwarrior in all date fields
 // (e.g. Entry, Due, Start). All date parsing and formatting in this
 // package uses this constant.
@@ -20,13 +26,97 @@ type Task struct {
 	Status      string       `json:"status"`
 	Start       string       `json:"start"`
 	Entry       string       `json:"entry"`
+	End         string       `json:"end"`
+	Modified    string       `json:"modified"`
 	Due         string       `json:"due"`
+	Scheduled   string       `json:"scheduled"`
+	Wait        string       `json:"wait"`
+	Until       string       `json:"until"`
 	Priority    string       `json:"priority"`
 	Recur       string       `json:"recur"`
 	Parent      string       `json:"parent"`
 	RType       string       `json:"rtype"`
 	Urgency     float64      `json:"urgency"`
 	Annotations []Annotation `json:"annotations"`
+	Depends     Dependencies `json:"depends,omitempty"`
+
+	// UDA holds the values of user defined attributes, keyed by name.
+	// Only string and numeric values are kept.
+	UDA map[string]string `json:"-"`
+}
+
+// Waiting reports whether t is hidden until a later date. Taskwarrior
+// reports such a task as "pending" until the next command updates its
+// status, so a wait date after now counts as waiting too.
+func (t Task) Waiting(now time.Time) bool {
+	return t.Status == "waiting" || dateAfter(t.Wait, now)
+}
+
+// coreAttributes are the attributes Taskwarrior itself defines; every other
+// exported attribute is a UDA.
+var coreAttributes = map[string]bool{
+	"id": true, "uuid": true, "description": true, "project": true,
+	"tags": true, "status": true, "start": true, "entry": true, "end": true,
+	"modified": true, "due": true, "scheduled": true, "wait": true,
+	"until": true, "priority": true, "recur": true, "parent": true,
+	"rtype": true, "mask": true, "imask": true, "last": true,
+	"template": true, "urgency": true, "annotations": true, "depends": true,
+}
+
+// UnmarshalJSON implements json.Unmarshaler, collecting UDAs into t.UDA.
+func (t *Task) UnmarshalJSON(data []byte) error {
+	type plain Task
+	if err := json.Unmarshal(data, (*plain)(t)); err != nil {
+		return err
+	}
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	t.UDA = nil
+	for name, value := range raw {
+		if coreAttributes[name] {
+			continue
+		}
+		var s string
+		if err := json.Unmarshal(value, &s); err != nil {
+			var n json.Number
+			if err := json.Unmarshal(value, &n); err != nil {
+				continue
+			}
+			s = n.String()
+		}
+		if t.UDA == nil {
+			t.UDA = make(map[string]string)
+		}
+		t.UDA[name] = s
+	}
+	return nil
+}
+
+// Dependencies holds the UUIDs of the tasks a task depends on. Taskwarrior
+// 2.x exports them as one comma-separated string while 3.x uses a JSON
+// array; both forms are accepted.
+type Dependencies []string
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (d *Dependencies) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*d = list
+		return nil
+	}
+	var joined string
+	if err := json.Unmarshal(data, &joined); err != nil {
+		return err
+	}
+	*d = nil
+	for _, uuid := range strings.Split(joined, ",") {
+		if uuid = strings.TrimSpace(uuid); uuid != "" {
+			*d = append(*d, uuid)
+		}
+	}
+	return nil
 }
 
 // RunResult contains the captured output from a task command invocation.
@@ -0,0 +1,39 @@
+package task
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestDependenciesUnmarshal(t *testing.T) {
+	tests := map[string][]string{
+		`{"depends":"a,b"}`:     {"a", "b"},
+		`{"depends":["a","b"]}`: {"a", "b"},
+		`{"depends":""}`:        nil,
+		`{}`:                    nil,
+	}
+	for data, want := range tests {
+		var tsk Task
+		if err := json.Unmarshal([]byte(data), &tsk); err != nil {
+			t.Fatalf("%s: %v", data, err)
+		}
+		if !reflect.DeepEqual([]string(tsk.Depends), want) {
+			t.Errorf("%s: got %q, want %q", data, tsk.Depends, want)
+		}
+	}
+}
+
+func TestTaskUnmarshalUDA(t *testing.T) {
+	var tsk Task
+	data := `{"uuid":"a","priority":"H","estimate":3,"size":"L","links":["x"],"urgency":4.2}`
+	if err := json.Unmarshal([]byte(data), &tsk); err != nil {
+		t.Fatal(err)
+	}
+	if want := map[string]string{"estimate": "3", "size": "L"}; !reflect.DeepEqual(tsk.UDA, want) {
+		t.Fatalf("UDA = %v, want %v", tsk.UDA, want)
+	}
+	if tsk.Priority != "H" || tsk.Urgency != 4.2 {
+		t.Fatalf("core fields lost: %+v", tsk)
+	}
+}
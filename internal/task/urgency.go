@@ -0,0 +1,277 @@
+package task
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// UrgencyConfig holds the urgency settings of Taskwarrior.
+type UrgencyConfig struct {
+	// Coefficients maps the part of an "urgency.<key>.coefficient" setting
+	// between the dots, e.g. "due", "user.tag.next" or "uda.priority.H",
+	// to its value.
+	Coefficients map[string]float64
+	// AgeMax is urgency.age.max: the age in days at which the age term
+	// reaches its full coefficient.
+	AgeMax float64
+}
+
+// UrgencyTerm is one weighted contribution to the urgency of a task.
+type UrgencyTerm struct {
+	Name        string  // e.g. "due", "tag +next" or "priority H"
+	Key         string  // the coefficient key in UrgencyConfig.Coefficients
+	Coefficient float64 // the configured weight
+	Factor      float64 // between 0 and 1; 1 for matching tags, projects and UDAs
+	Value       float64 // Coefficient × Factor
+}
+
+// builtinUrgencyTerms lists the terms Taskwarrior computes for every task,
+// in the order of its urgency calculation.
+var builtinUrgencyTerms = []string{
+	"project", "active", "scheduled", "waiting", "blocked",
+	"annotations", "tags", "blocking", "due", "age",
+}
+
+// LoadUrgencyConfig reads the urgency coefficients from "task _show", which
+// lists the defaults merged with .taskrc.
+func LoadUrgencyConfig(ctx context.Context) (UrgencyConfig, error) {
+	result, err := RunArgs(ctx, []string{"_show"})
+	if err != nil {
+		return UrgencyConfig{}, err
+	}
+	return parseUrgencyConfig(result.Stdout), nil
+}
+
+// SetConfigContext writes a setting to .taskrc with "task config", without
+// asking for confirmation.
+func SetConfigContext(ctx context.Context, name, value string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("config name cannot be empty")
+	}
+	return runContext(ctx, "rc.confirmation=no", "config", name, value)
+}
+
+// parseUrgencyConfig extracts the urgency settings from "key=value" lines.
+func parseUrgencyConfig(out string) UrgencyConfig {
+	cfg := UrgencyConfig{Coefficients: make(map[string]float64)}
+	for _, line := range strings.Split(out, "\n") {
+		key, value, ok := strings.Cut(strings.TrimSpace(line), "=")
+		if !ok {
+			continue
+		}
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			continue
+		}
+		if key == "urgency.age.max" {
+			cfg.AgeMax = n
+			continue
+		}
+		name, ok := strings.CutPrefix(key, "urgency.")
+		if !ok {
+			continue
+		}
+		if name, ok = strings.CutSuffix(name, ".coefficient"); ok {
+			cfg.Coefficients[name] = n
+		}
+	}
+	return cfg
+}
+
+// Clone returns a copy of c whose coefficients can be changed without
+// affecting c.
+func (c UrgencyConfig) Clone() UrgencyConfig {
+	clone := UrgencyConfig{Coefficients: make(map[string]float64, len(c.Coefficients)), AgeMax: c.AgeMax}
+	for key, value := range c.Coefficients {
+		clone.Coefficients[key] = value
+	}
+	return clone
+}
+
+// RetuneUrgency recomputes the urgency of tasks as if Taskwarrior used the
+// coefficients of to instead of from. Only the difference between the two
+// breakdowns is applied, so contributions the terms do not cover are kept.
+// Blocking and blocked are judged within tasks.
+func RetuneUrgency(tasks []Task, from, to UrgencyConfig, now time.Time) {
+	blocking, blocked := DependencyState(tasks)
+	for i, t := range tasks {
+		before := SumUrgency(from.UrgencyTerms(t, blocking[t.UUID], blocked[t.UUID], now))
+		after := SumUrgency(to.UrgencyTerms(t, blocking[t.UUID], blocked[t.UUID], now))
+		tasks[i].Urgency += after - before
+	}
+}
+
+// DependencyState reports which of the given tasks block a pending task and
+// which depend on a pending task.
+func DependencyState(tasks []Task) (blocking, blocked map[string]bool) {
+	pending := make(map[string]bool)
+	for _, t := range tasks {
+		if t.Status == "pending" || t.Status == "waiting" {
+			pending[t.UUID] = true
+		}
+	}
+	blocking, blocked = make(map[string]bool), make(map[string]bool)
+	for _, t := range tasks {
+		if !pending[t.UUID] {
+			continue
+		}
+		for _, dep := range t.Depends {
+			if pending[dep] {
+				blocking[dep] = true
+				blocked[t.UUID] = true
+			}
+		}
+	}
+	return blocking, blocked
+}
+
+// UrgencyTerms breaks the urgency of t down into Taskwarrior's weighted
+// terms. The built-in terms are always listed, even when they contribute
+// nothing; tag, project, keyword and UDA coefficients only when they match.
+// Their sum equals Taskwarrior's urgency unless Taskwarrior applies rules not
+// covered here, such as urgency.inherit.
+func (c UrgencyConfig) UrgencyTerms(t Task, blocking, blocked bool, now time.Time) []UrgencyTerm {
+	flag := func(b bool) float64 {
+		if b {
+			return 1
+		}
+		return 0
+	}
+	factors := map[string]float64{
+		"project":     flag(t.Project != ""),
+		"active":      flag(t.Start != ""),
+		"scheduled":   flag(dateBefore(t.Scheduled, now)),
+		"waiting":     flag(t.Waiting(now)),
+		"blocked":     flag(blocked),
+		"annotations": countFactor(len(t.Annotations)),
+		"tags":        countFactor(len(t.Tags)),
+		"blocking":    flag(blocking),
+		"due":         dueFactor(t.Due, now),
+		"age":         ageFactor(t.Entry, c.AgeMax, now),
+	}
+
+	var terms []UrgencyTerm
+	add := func(name, key string, factor float64) {
+		coef := c.Coefficients[key]
+		terms = append(terms, UrgencyTerm{Name: name, Key: key, Coefficient: coef, Factor: factor, Value: coef * factor})
+	}
+	for _, key := range builtinUrgencyTerms {
+		add(key, key, factors[key])
+	}
+
+	keys := make([]string, 0, len(c.Coefficients))
+	for key := range c.Coefficients {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if name, ok := matchUrgencyKey(t, key); ok {
+			add(name, key, 1)
+		}
+	}
+	return terms
+}
+
+// matchUrgencyKey reports whether a tag, project, keyword or UDA coefficient
+// applies to t and returns the term's display name.
+func matchUrgencyKey(t Task, key string) (string, bool) {
+	if tag, ok := strings.CutPrefix(key, "user.tag."); ok {
+		for _, have := range t.Tags {
+			if have == tag {
+				return "tag +" + tag, true
+			}
+		}
+		return "", false
+	}
+	if project, ok := strings.CutPrefix(key, "user.project."); ok {
+		return "project " + project, project != "" && strings.HasPrefix(t.Project, project)
+	}
+	if keyword, ok := strings.CutPrefix(key, "user.keyword."); ok {
+		return "keyword " + keyword, keyword != "" && strings.Contains(t.Description, keyword)
+	}
+	rest, ok := strings.CutPrefix(key, "uda.")
+	if !ok {
+		return "", false
+	}
+	name, want, byValue := strings.Cut(rest, ".")
+	value := t.UDA[name]
+	if name == "priority" {
+		value = t.Priority
+	}
+	switch {
+	case !byValue:
+		return name, value != ""
+	case want == "":
+		return name + " (none)", value == ""
+	default:
+		return name + " " + want, value == want
+	}
+}
+
+// SumUrgency returns the total of the given terms.
+func SumUrgency(terms []UrgencyTerm) float64 {
+	var sum float64
+	for _, term := range terms {
+		sum += term.Value
+	}
+	return sum
+}
+
+// countFactor is Taskwarrior's factor for the number of tags or
+// annotations.
+func countFactor(n int) float64 {
+	switch {
+	case n <= 0:
+		return 0
+	case n == 1:
+		return 0.8
+	case n == 2:
+		return 0.9
+	}
+	return 1
+}
+
+// dueFactor grows linearly from 0.2 two weeks before the due date to 1.0 a
+// week after it.
+func dueFactor(due string, now time.Time) float64 {
+	ts, err := time.Parse(DateFormat, due)
+	if err != nil {
+		return 0
+	}
+	overdue := now.Sub(ts).Hours() / 24
+	switch {
+	case overdue >= 7:
+		return 1
+	case overdue >= -14:
+		return (overdue+14)*0.8/21 + 0.2
+	}
+	return 0.2
+}
+
+// ageFactor grows linearly from 0 at entry to 1.0 at maxDays.
+func ageFactor(entry string, maxDays float64, now time.Time) float64 {
+	ts, err := time.Parse(DateFormat, entry)
+	if err != nil {
+		return 0
+	}
+	if maxDays == 0 {
+		return 1
+	}
+	age := now.Sub(ts).Hours() / 24
+	return math.Min(math.Max(age/maxDays, 0), 1)
+}
+
+func dateBefore(date string, now time.Time) bool {
+	ts, err := time.Parse(DateFormat, date)
+	return err == nil && ts.Before(now)
+}
+
+func dateAfter(date string, now time.Time) bool {
+	ts, err := time.Parse(DateFormat, date)
+	return err == nil && ts.After(now)
+}
@@ -0,0 +1,127 @@
+package task
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+const showOutput = `Config Variable=Value
+urgency.active.coefficient=4.0
+urgency.age.coefficient=2.0
+urgency.age.max=365
+urgency.annotations.coefficient=1.0
+urgency.blocked.coefficient=-5.0
+urgency.blocking.coefficient=8.0
+urgency.due.coefficient=12.0
+urgency.project.coefficient=1.0
+urgency.scheduled.coefficient=5.0
+urgency.tags.coefficient=1.0
+urgency.waiting.coefficient=-3.0
+urgency.inherit=0
+urgency.uda.priority.H.coefficient=6.0
+urgency.uda.priority.L.coefficient=1.8
+urgency.uda.priority.M.coefficient=3.9
+urgency.uda.estimate.coefficient=0.5
+urgency.user.project.work.coefficient=2.5
+urgency.user.tag.next.coefficient=15.0
+urgency.user.keyword.urgent.coefficient=1.5
+verbose=on
+`
+
+func TestParseUrgencyConfig(t *testing.T) {
+	cfg := parseUrgencyConfig(showOutput)
+	if cfg.AgeMax != 365 {
+		t.Fatalf("AgeMax = %v", cfg.AgeMax)
+	}
+	if len(cfg.Coefficients) != 17 || cfg.Coefficients["user.tag.next"] != 15 || cfg.Coefficients["uda.priority.H"] != 6 {
+		t.Fatalf("coefficients = %v", cfg.Coefficients)
+	}
+}
+
+func TestUrgencyTerms(t *testing.T) {
+	cfg := parseUrgencyConfig(showOutput)
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tsk := Task{
+		UUID:        "a",
+		Description: "urgent fix",
+		Project:     "work.web",
+		Tags:        []string{"next", "bug"},
+		Status:      "pending",
+		Start:       "20261018T080000Z",
+		Due:         "20261025T120000Z",                        // 7 days ahead
+		Entry:       now.AddDate(0, 0, -73).Format(DateFormat), // a fifth of age.max
+		Priority:    "H",
+		Annotations: []Annotation{{Description: "x"}},
+		UDA:         map[string]string{"estimate": "3"},
+	}
+	terms := cfg.UrgencyTerms(tsk, true, false, now)
+	values := make(map[string]float64)
+	for _, term := range terms {
+		values[term.Name] = term.Value
+	}
+	want := map[string]float64{
+		"project":        1,
+		"active":         4,
+		"scheduled":      0,
+		"blocked":        0,
+		"annotations":    0.8,
+		"tags":           0.9,
+		"blocking":       8,
+		"due":            12 * (7*0.8/21 + 0.2),
+		"age":            0.4,
+		"tag +next":      15,
+		"project work":   2.5,
+		"keyword urgent": 1.5,
+		"priority H":     6,
+		"estimate":       0.5,
+	}
+	for name, v := range want {
+		if math.Abs(values[name]-v) > 1e-9 {
+			t.Errorf("%s = %v, want %v", name, values[name], v)
+		}
+	}
+	if _, ok := values["priority M"]; ok {
+		t.Errorf("non-matching priority term listed")
+	}
+	if len(terms) != len(builtinUrgencyTerms)+5 {
+		t.Errorf("got %d terms: %+v", len(terms), terms)
+	}
+	var sum float64
+	for _, v := range want {
+		sum += v
+	}
+	if math.Abs(SumUrgency(terms)-sum) > 1e-9 {
+		t.Errorf("SumUrgency = %v, want %v", SumUrgency(terms), sum)
+	}
+}
+
+func TestDependencyState(t *testing.T) {
+	tasks := []Task{
+		{UUID: "a", Status: "pending", Depends: Dependencies{"b", "c"}},
+		{UUID: "b", Status: "pending"},
+		{UUID: "c", Status: "completed"},
+		{UUID: "d", Status: "completed", Depends: Dependencies{"b"}},
+	}
+	blocking, blocked := DependencyState(tasks)
+	if len(blocking) != 1 || !blocking["b"] || len(blocked) != 1 || !blocked["a"] {
+		t.Fatalf("blocking = %v, blocked = %v", blocking, blocked)
+	}
+}
+
+func TestRetuneUrgency(t *testing.T) {
+	base := UrgencyConfig{Coefficients: map[string]float64{"user.tag.x": 1, "active": 4}}
+	tuned := base.Clone()
+	tuned.Coefficients["user.tag.x"] = 6
+	tasks := []Task{
+		{UUID: "a", Status: "pending", Urgency: 5.5, Start: "20261018T080000Z"},
+		{UUID: "b", Status: "pending", Urgency: 3, Tags: []string{"x"}},
+	}
+	RetuneUrgency(tasks, base, tuned, time.Now())
+	if base.Coefficients["user.tag.x"] != 1 {
+		t.Fatalf("Clone shares coefficients")
+	}
+	if tasks[0].Urgency != 5.5 || tasks[1].Urgency != 8 {
+		t.Fatalf("urgency = %v, %v", tasks[0].Urgency, tasks[1].Urgency)
+	}
+}
@@ -0,0 +1,216 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+)
+
+// Fields of the multi-field add form, in display and Tab order.
+const (
+	addFormDescription = iota
+	addFormProject
+	addFormTags
+	addFormPriority
+	addFormDue
+	addFormRecur
+	addFormFieldCount
+)
+
+var addFormLabels = [addFormFieldCount]string{
+	addFormDescription: "description",
+	addFormProject:     "project",
+	addFormTags:        "tags",
+	addFormPriority:    "priority",
+	addFormDue:         "due",
+	addFormRecur:       "recur",
+}
+
+// Static completions for fields Taskwarrior has no helper command for.
+var (
+	addFormDueSuggestions = []string{
+		"today", "tomorrow", "yesterday", "now", "eod", "eow", "eom", "eoy",
+		"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday",
+	}
+	addFormRecurSuggestions = []string{"daily", "weekly", "biweekly", "monthly", "bimonthly", "yearly"}
+)
+
+func newAddFormInputs() [addFormFieldCount]textinput.Model {
+	var inputs [addFormFieldCount]textinput.Model
+	for i := range inputs {
+		inputs[i] = textinput.New()
+		inputs[i].Prompt = fmt.Sprintf("%-12s ", addFormLabels[i]+":")
+		inputs[i].ShowSuggestions = true
+	}
+	return inputs
+}
+
+// handleAddTaskForm opens the form-based alternative to the "+" add prompt.
+func (m *Model) handleAddTaskForm() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	m.addFormActive = true
+	for i := range m.addFormInputs {
+		m.addFormInputs[i].SetValue("")
+		m.addFormInputs[i].Blur()
+	}
+	m.focusAddFormField(addFormDescription)
+	m.refreshAddFormSuggestions()
+	m.updateTableHeight()
+	return m, m.loadShellCompletionsCmd()
+}
+
+func (m *Model) focusAddFormField(field int) {
+	m.addFormInputs[m.addFormFocus].Blur()
+	m.addFormFocus = (field + addFormFieldCount) % addFormFieldCount
+	m.addFormInputs[m.addFormFocus].Focus()
+}
+
+// handleAddFormMode handles keys while the add form is open. Tab accepts
+// the inline completion when one is shown and otherwise moves to the next
+// field; Shift+Tab moves back. Enter validates every field and submits.
+func (m *Model) handleAddFormMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	input := &m.addFormInputs[m.addFormFocus]
+	switch msg.String() {
+	case "esc":
+		m.closeAddForm()
+		return m, nil
+	case "enter":
+		return m.submitAddForm()
+	case "shift+tab":
+		m.focusAddFormField(m.addFormFocus - 1)
+		return m, nil
+	case "tab":
+		if s := input.CurrentSuggestion(); s == "" || s == input.Value() {
+			m.focusAddFormField(m.addFormFocus + 1)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	*input, cmd = input.Update(msg)
+	if m.addFormFocus == addFormTags {
+		m.refreshAddFormSuggestions()
+	}
+	return m, cmd
+}
+
+func (m *Model) closeAddForm() {
+	m.addFormActive = false
+	m.addFormInputs[m.addFormFocus].Blur()
+	m.updateTableHeight()
+}
+
+// refreshAddFormSuggestions sets per-field completions. Projects and tags
+// come from the Taskwarrior CompletionSources shared with the command
+// prompt; tags complete the last word so several can be entered.
+func (m *Model) refreshAddFormSuggestions() {
+	m.addFormInputs[addFormProject].SetSuggestions(m.shellCompletion.Projects)
+	m.addFormInputs[addFormPriority].SetSuggestions([]string{"H", "M", "L"})
+	m.addFormInputs[addFormDue].SetSuggestions(addFormDueSuggestions)
+	m.addFormInputs[addFormRecur].SetSuggestions(addFormRecurSuggestions)
+
+	value := m.addFormInputs[addFormTags].Value()
+	prefix := value[:strings.LastIndex(value, " ")+1]
+	tags := make([]string, 0, len(m.shellCompletion.Tags))
+	for _, tag := range m.shellCompletion.Tags {
+		tags = append(tags, prefix+tag)
+	}
+	m.addFormInputs[addFormTags].SetSuggestions(tags)
+}
+
+// addFormArgs validates the form and converts it into "task add"
+// arguments. On failure it returns the offending field so it can be
+// focused. The description is passed after "--" so words in it that look
+// like modifiers (e.g. "due:") are kept verbatim.
+func (m *Model) addFormArgs() ([]string, int, error) {
+	value := func(field int) string { return strings.TrimSpace(m.addFormInputs[field].Value()) }
+
+	desc := value(addFormDescription)
+	if err := validateDescription(desc); err != nil {
+		return nil, addFormDescription, err
+	}
+
+	var args []string
+	if project := value(addFormProject); project != "" {
+		if strings.ContainsAny(project, " \t") {
+			return nil, addFormProject, fmt.Errorf("project cannot contain whitespace")
+		}
+		args = append(args, "project:"+project)
+	}
+	for _, tag := range strings.Fields(value(addFormTags)) {
+		tag = strings.TrimPrefix(tag, "+")
+		if err := validateTagName(tag); err != nil {
+			return nil, addFormTags, err
+		}
+		args = append(args, "+"+tag)
+	}
+	priority := strings.ToUpper(value(addFormPriority))
+	if err := validatePriority(priority); err != nil {
+		return nil, addFormPriority, err
+	}
+	if priority != "" {
+		args = append(args, "priority:"+priority)
+	}
+	due := value(addFormDue)
+	if err := validateDueDate(due); err != nil {
+		return nil, addFormDue, err
+	}
+	if due != "" {
+		args = append(args, "due:"+due)
+	}
+	recur := value(addFormRecur)
+	if err := validateRecurrence(recur); err != nil {
+		return nil, addFormRecur, err
+	}
+	if recur != "" {
+		if due == "" {
+			return nil, addFormDue, fmt.Errorf("recurring tasks need a due date")
+		}
+		args = append(args, "recur:"+recur)
+	}
+	return append(args, "--", desc), 0, nil
+}
+
+// submitAddForm creates the task with a single "task add" call and selects
+// and blinks it like the "+" prompt does.
+func (m *Model) submitAddForm() (tea.Model, tea.Cmd) {
+	args, field, err := m.addFormArgs()
+	if err != nil {
+		m.focusAddFormField(field)
+		return m, m.showErrorTimed(err)
+	}
+
+	ctx, cancel := m.taskOperationContext()
+	err = m.taskwarriorClient().AddArgsContext(ctx, args)
+	cancel()
+	if err != nil {
+		return m, m.showErrorTimed(err)
+	}
+
+	m.closeAddForm()
+	newTask, err := m.latestTask()
+	if err != nil {
+		m.showError(err)
+	}
+	if !m.reloadAndReport() || err != nil {
+		return m, nil
+	}
+	if m.taskByID(newTask.ID) == nil {
+		m.statusMsg = "Task added; the current filter does not list it"
+		return m, nil
+	}
+	m.selectTaskByID(newTask.ID)
+	return m, m.startBlink(newTask.ID, false)
+}
+
+// addFormView renders the form, one labelled input per line.
+func (m *Model) addFormView() string {
+	lines := make([]string, 0, addFormFieldCount)
+	for i := range m.addFormInputs {
+		lines = append(lines, m.addFormInputs[i].View())
+	}
+	return strings.Join(lines, "\n")
+}
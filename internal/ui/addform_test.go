@@ -0,0 +1,146 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func newAddFormTestModel(t *testing.T) (*Model, *fakeTaskwarrior) {
+	t.Helper()
+	fake := &fakeTaskwarrior{tasks: []task.Task{{ID: 1, UUID: "a", Description: "existing", Status: "pending"}}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	// Pretend completions were already loaded so no async command runs.
+	m.shellCompletion = task.CompletionSources{
+		Commands: []string{"add"},
+		Projects: []string{"home", "work"},
+		Tags:     []string{"errand", "urgent"},
+	}
+	m.handleAddTaskForm()
+	return &m, fake
+}
+
+// TestAddFormSubmitsSingleAdd fills every field and checks that exactly one
+// "task add" call is made with the expected modifiers.
+func TestAddFormSubmitsSingleAdd(t *testing.T) {
+	m, fake := newAddFormTestModel(t)
+	values := [addFormFieldCount]string{"Pay due: bills", "home", "errand +urgent", "h", "tomorrow", "monthly"}
+	for i, v := range values {
+		m.addFormInputs[i].SetValue(v)
+	}
+
+	m.handleAddFormMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	want := []string{"project:home", "+errand", "+urgent", "priority:H", "due:tomorrow", "recur:monthly", "--", "Pay due: bills"}
+	if len(fake.addArgs) != 1 || !reflect.DeepEqual(fake.addArgs[0], want) {
+		t.Fatalf("unexpected add calls %q, want one with %q", fake.addArgs, want)
+	}
+	if m.addFormActive {
+		t.Fatalf("expected form to close after submitting")
+	}
+	if got := m.getTaskAtCursor(); got == nil || got.ID != 2 {
+		t.Fatalf("expected the new task to be selected, got %+v", got)
+	}
+}
+
+// TestAddFormTaskOutsideFilter checks that a task the current filter does
+// not list is reported instead of selecting another row.
+func TestAddFormTaskOutsideFilter(t *testing.T) {
+	fake := &unlistedNewTasksFake{fakeTaskwarrior: &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "a", Description: "existing", Status: "pending"},
+	}}, listed: 1}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.shellCompletion = task.CompletionSources{Commands: []string{"add"}}
+	m.handleAddTaskForm()
+	m.addFormInputs[0].SetValue("Hidden")
+	m.handleAddFormMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if !strings.Contains(m.statusMsg, "does not list it") || m.blinkID != 0 {
+		t.Fatalf("expected the unlisted task to be reported, got %q", m.statusMsg)
+	}
+}
+
+// TestAddFormValidation checks that invalid fields keep the form open, focus
+// the offending field, and do not call Taskwarrior.
+func TestAddFormValidation(t *testing.T) {
+	tests := []struct {
+		name  string
+		field int
+		value string
+		want  string
+	}{
+		{name: "description", field: addFormDescription, value: " ", want: "description"},
+		{name: "priority", field: addFormPriority, value: "X", want: "priority"},
+		{name: "due", field: addFormDue, value: "someday-ish", want: "due"},
+		{name: "recur without due", field: addFormRecur, value: "weekly", want: "due date"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, fake := newAddFormTestModel(t)
+			m.addFormInputs[addFormDescription].SetValue("valid")
+			m.addFormInputs[tt.field].SetValue(tt.value)
+
+			m.handleAddFormMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+			if len(fake.addArgs) != 0 {
+				t.Fatalf("expected no add call, got %q", fake.addArgs)
+			}
+			if !m.addFormActive {
+				t.Fatalf("expected form to stay open")
+			}
+			if !strings.Contains(m.statusMsg, tt.want) {
+				t.Fatalf("expected error mentioning %q, got %q", tt.want, m.statusMsg)
+			}
+			wantFocus := tt.field
+			if tt.field == addFormRecur {
+				wantFocus = addFormDue
+			}
+			if m.addFormFocus != wantFocus {
+				t.Fatalf("expected focus on field %d, got %d", wantFocus, m.addFormFocus)
+			}
+		})
+	}
+}
+
+// TestAddFormTabCompletesThenAdvances checks that Tab first accepts a shown
+// completion and moves to the next field once the value is complete.
+func TestAddFormTabCompletesThenAdvances(t *testing.T) {
+	m, _ := newAddFormTestModel(t)
+	tab := tea.KeyPressMsg{Code: tea.KeyTab}
+
+	m.handleAddFormMode(tab)
+	if m.addFormFocus != addFormProject {
+		t.Fatalf("expected Tab on empty description to advance, focus=%d", m.addFormFocus)
+	}
+
+	m.handleAddFormMode(tea.KeyPressMsg{Code: 'w', Text: "w"})
+	m.handleAddFormMode(tab)
+	if got := m.addFormInputs[addFormProject].Value(); got != "work" {
+		t.Fatalf("expected project completion to work, got %q", got)
+	}
+	m.handleAddFormMode(tab)
+	if m.addFormFocus != addFormTags {
+		t.Fatalf("expected second Tab to advance to tags, focus=%d", m.addFormFocus)
+	}
+
+	m.addFormInputs[addFormTags].SetValue("errand u")
+	m.refreshAddFormSuggestions()
+	m.handleAddFormMode(tab)
+	if got := m.addFormInputs[addFormTags].Value(); got != "errand urgent" {
+		t.Fatalf("expected last tag to complete, got %q", got)
+	}
+
+	m.handleAddFormMode(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	if m.addFormFocus != addFormProject {
+		t.Fatalf("expected Shift+Tab to go back, focus=%d", m.addFormFocus)
+	}
+}
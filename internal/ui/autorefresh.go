@@ -0,0 +1,210 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// autoRefreshMinInterval is the lower bound for any auto-refresh delay, both
+// for user-entered values and for the adaptive speed-up after a change.
+const autoRefreshMinInterval = 2 * time.Second
+
+// autoRefreshMaxBackoff caps the adaptive back-off at this multiple of the
+// configured base interval.
+const autoRefreshMaxBackoff = 8
+
+// SetAutoRefresh enables or disables the periodic reload before the program
+// starts. When enabled, Init schedules the first tick.
+func (m *Model) SetAutoRefresh(enabled bool) {
+	m.autoRefresh = enabled
+	if enabled {
+		m.restartAutoRefresh()
+	}
+}
+
+// SetAutoRefreshInterval configures the base delay between automatic
+// reloads. Values below autoRefreshMinInterval are rejected so a typo cannot
+// hammer Taskwarrior with exports.
+func (m *Model) SetAutoRefreshInterval(interval time.Duration) error {
+	if interval < autoRefreshMinInterval {
+		return fmt.Errorf("auto-refresh interval %s is shorter than %s", interval, autoRefreshMinInterval)
+	}
+	m.autoRefreshInterval = interval
+	m.autoRefreshCurrent = 0
+	return nil
+}
+
+// SetAutoRefreshAdaptive enables or disables the adaptive back-off.
+func (m *Model) SetAutoRefreshAdaptive(adaptive bool) {
+	m.autoRefreshAdaptive = adaptive
+	m.autoRefreshCurrent = 0
+}
+
+// baseAutoRefreshInterval returns the configured interval, falling back to
+// the default when none has been set.
+func (m *Model) baseAutoRefreshInterval() time.Duration {
+	if m.autoRefreshInterval <= 0 {
+		return autoRefreshDefaultInterval
+	}
+	return m.autoRefreshInterval
+}
+
+// currentAutoRefreshInterval returns the delay until the next tick. In fixed
+// mode this is the base interval; in adaptive mode it is the backed-off (or
+// sped-up) value computed by adaptAutoRefreshInterval.
+func (m *Model) currentAutoRefreshInterval() time.Duration {
+	if m.autoRefreshAdaptive && m.autoRefreshCurrent > 0 {
+		return m.autoRefreshCurrent
+	}
+	return m.baseAutoRefreshInterval()
+}
+
+// restartAutoRefresh prepares a fresh reload loop: the interval is defaulted,
+// the adaptive state is reset, the current task list becomes the baseline for
+// change detection, and the generation is bumped so ticks from an older loop
+// are dropped.
+func (m *Model) restartAutoRefresh() {
+	if m.autoRefreshInterval <= 0 {
+		m.autoRefreshInterval = autoRefreshDefaultInterval
+	}
+	m.autoRefreshCurrent = 0
+	m.autoRefreshHash = tasksContentHash(m.tasks)
+	m.autoRefreshGen++
+}
+
+// adaptAutoRefreshInterval compares the freshly loaded task list with the
+// one seen on the previous tick and adjusts the adaptive delay accordingly.
+func (m *Model) adaptAutoRefreshInterval() {
+	hash := tasksContentHash(m.tasks)
+	changed := hash != m.autoRefreshHash
+	m.autoRefreshHash = hash
+	if !m.autoRefreshAdaptive {
+		return
+	}
+	m.autoRefreshCurrent = nextAdaptiveInterval(m.currentAutoRefreshInterval(), m.baseAutoRefreshInterval(), changed)
+}
+
+// nextAdaptiveInterval doubles current while nothing changes (capped at
+// autoRefreshMaxBackoff times base) and drops to half the base interval
+// (but not below autoRefreshMinInterval) as soon as a change is seen, so
+// bursts of external edits are picked up quickly.
+func nextAdaptiveInterval(current, base time.Duration, changed bool) time.Duration {
+	if changed {
+		floor := min(base, autoRefreshMinInterval)
+		return max(base/2, floor)
+	}
+	return min(current*2, base*autoRefreshMaxBackoff)
+}
+
+// tasksContentHash returns a content hash of tasks used to detect whether an
+// export differs from the previous one. Urgency is ignored because its age
+// term drifts on every export even when nothing was modified.
+func tasksContentHash(tasks []task.Task) uint64 {
+	h := fnv.New64a()
+	enc := json.NewEncoder(h)
+	for _, tsk := range tasks {
+		tsk.Urgency = 0
+		_ = enc.Encode(tsk)
+	}
+	return h.Sum64()
+}
+
+// autoRefreshIndicator returns the persistent status-line text describing the
+// auto-refresh loop, or "" when it is disabled.
+func (m *Model) autoRefreshIndicator() string {
+	if !m.autoRefresh {
+		return ""
+	}
+	if m.autoRefreshAdaptive {
+		return fmt.Sprintf("auto-refresh: on (%s, adaptive)", m.currentAutoRefreshInterval())
+	}
+	return fmt.Sprintf("auto-refresh: on (%s)", m.currentAutoRefreshInterval())
+}
+
+// autoRefreshSettingText renders the current settings in the format accepted
+// by parseAutoRefreshSetting so the prompt can be pre-filled with them.
+func (m *Model) autoRefreshSettingText() string {
+	text := m.baseAutoRefreshInterval().String()
+	if m.autoRefreshAdaptive {
+		text += " adaptive"
+	}
+	return text
+}
+
+// parseAutoRefreshSetting parses the interval prompt. It accepts a Go
+// duration ("30s", "2m") and/or one of the mode keywords "adaptive" and
+// "fixed", in any order. Omitted parts keep their current value.
+func parseAutoRefreshSetting(value string, interval time.Duration, adaptive bool) (time.Duration, bool, error) {
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return 0, false, fmt.Errorf("enter an interval such as 30s and/or adaptive|fixed")
+	}
+	for _, field := range fields {
+		switch strings.ToLower(field) {
+		case "adaptive", "auto":
+			adaptive = true
+			continue
+		case "fixed":
+			adaptive = false
+			continue
+		}
+		d, err := time.ParseDuration(field)
+		if err != nil {
+			return 0, false, fmt.Errorf("invalid auto-refresh interval %q", field)
+		}
+		if d < autoRefreshMinInterval {
+			return 0, false, fmt.Errorf("auto-refresh interval %s is shorter than %s", d, autoRefreshMinInterval)
+		}
+		interval = d
+	}
+	return interval, adaptive, nil
+}
+
+// handleAutoRefreshIntervalPrompt opens the inline prompt used to change the
+// auto-refresh interval and mode at runtime.
+func (m *Model) handleAutoRefreshIntervalPrompt() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.refreshIntervalEditing = true
+	m.refreshIntervalInput.SetValue(m.autoRefreshSettingText())
+	m.refreshIntervalInput.CursorEnd()
+	m.refreshIntervalInput.Focus()
+	m.updateTableHeight()
+	return m, nil
+}
+
+// handleAutoRefreshIntervalMode applies the entered interval. Setting an
+// interval also switches auto-refresh on, restarting the loop so the new
+// delay takes effect immediately instead of after the pending tick.
+func (m *Model) handleAutoRefreshIntervalMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	var started tea.Cmd
+	onEnter := func(value string) error {
+		interval, adaptive, err := parseAutoRefreshSetting(value, m.baseAutoRefreshInterval(), m.autoRefreshAdaptive)
+		if err != nil {
+			return err
+		}
+		m.autoRefreshInterval = interval
+		m.autoRefreshAdaptive = adaptive
+		m.autoRefresh = true
+		m.restartAutoRefresh()
+		started = autoRefreshCmd(m.currentAutoRefreshInterval(), m.autoRefreshGen)
+		return nil
+	}
+
+	onExit := func() {
+		m.refreshIntervalEditing = false
+	}
+
+	model, cmd := m.handleTextInput(msg, &m.refreshIntervalInput, onEnter, onExit)
+	if started != nil {
+		m.statusMsg = "Auto-refresh " + strings.TrimPrefix(m.autoRefreshIndicator(), "auto-refresh: ")
+		return model, started
+	}
+	return model, cmd
+}
@@ -5,6 +5,9 @@ import (
 	"testing"
 	"time"
 
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
 	"codeberg.org/snonux/tasksamurai/internal/task"
 )
 
@@ -156,3 +159,111 @@ func TestUltraModeStatusAutoRefreshIndicator(t *testing.T) {
 		t.Fatalf("expected auto-refresh indicator in ultra status when enabled")
 	}
 }
+
+// TestParseAutoRefreshSetting covers the interval prompt syntax: durations,
+// mode keywords, combinations, and rejected values.
+func TestParseAutoRefreshSetting(t *testing.T) {
+	tests := []struct {
+		in           string
+		wantInterval time.Duration
+		wantAdaptive bool
+		wantErr      bool
+	}{
+		{in: "30s", wantInterval: 30 * time.Second},
+		{in: "adaptive", wantInterval: 10 * time.Second, wantAdaptive: true},
+		{in: "2m adaptive", wantInterval: 2 * time.Minute, wantAdaptive: true},
+		{in: "fixed 15s", wantInterval: 15 * time.Second},
+		{in: "", wantErr: true},
+		{in: "soon", wantErr: true},
+		{in: "500ms", wantErr: true},
+	}
+	for _, tt := range tests {
+		interval, adaptive, err := parseAutoRefreshSetting(tt.in, 10*time.Second, false)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%q: expected error", tt.in)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", tt.in, err)
+			continue
+		}
+		if interval != tt.wantInterval || adaptive != tt.wantAdaptive {
+			t.Errorf("%q: got (%s, %v), want (%s, %v)", tt.in, interval, adaptive, tt.wantInterval, tt.wantAdaptive)
+		}
+	}
+}
+
+// TestNextAdaptiveInterval verifies the back-off doubles up to the cap while
+// nothing changes and snaps back below the base interval after a change.
+func TestNextAdaptiveInterval(t *testing.T) {
+	base := 10 * time.Second
+	if got := nextAdaptiveInterval(base, base, false); got != 20*time.Second {
+		t.Fatalf("expected doubling to 20s, got %s", got)
+	}
+	if got := nextAdaptiveInterval(base*autoRefreshMaxBackoff, base, false); got != base*autoRefreshMaxBackoff {
+		t.Fatalf("expected back-off capped at %s, got %s", base*autoRefreshMaxBackoff, got)
+	}
+	if got := nextAdaptiveInterval(40*time.Second, base, true); got != 5*time.Second {
+		t.Fatalf("expected speed-up to 5s after a change, got %s", got)
+	}
+	if got := nextAdaptiveInterval(3*time.Second, 3*time.Second, true); got != autoRefreshMinInterval {
+		t.Fatalf("expected speed-up floored at %s, got %s", autoRefreshMinInterval, got)
+	}
+}
+
+// TestAdaptAutoRefreshInterval checks that the adaptive delay reacts to
+// changes in the loaded task list and ignores urgency drift.
+func TestAdaptAutoRefreshInterval(t *testing.T) {
+	m := Model{}
+	m.tasks = []task.Task{{ID: 1, UUID: "a", Description: "one", Urgency: 1}}
+	m.SetAutoRefreshAdaptive(true)
+	if err := m.SetAutoRefreshInterval(10 * time.Second); err != nil {
+		t.Fatalf("SetAutoRefreshInterval: %v", err)
+	}
+	m.SetAutoRefresh(true)
+
+	// Only urgency drifted: treated as unchanged, so the delay backs off.
+	m.tasks[0].Urgency = 1.5
+	m.adaptAutoRefreshInterval()
+	if got := m.currentAutoRefreshInterval(); got != 20*time.Second {
+		t.Fatalf("expected back-off to 20s, got %s", got)
+	}
+	if !strings.Contains(m.autoRefreshIndicator(), "20s, adaptive") {
+		t.Fatalf("expected adaptive indicator, got %q", m.autoRefreshIndicator())
+	}
+
+	// A real modification speeds the loop up again.
+	m.tasks[0].Description = "changed"
+	m.adaptAutoRefreshInterval()
+	if got := m.currentAutoRefreshInterval(); got != 5*time.Second {
+		t.Fatalf("expected speed-up to 5s, got %s", got)
+	}
+}
+
+// TestAutoRefreshIntervalPrompt verifies that entering an interval in the
+// prompt enables auto-refresh with the new settings and schedules a tick.
+func TestAutoRefreshIntervalPrompt(t *testing.T) {
+	m := Model{}
+	m.refreshIntervalInput = textinput.New()
+	m.handleAutoRefreshIntervalPrompt()
+	if !m.refreshIntervalEditing {
+		t.Fatalf("expected interval prompt to be active")
+	}
+	if got := m.refreshIntervalInput.Value(); got != autoRefreshDefaultInterval.String() {
+		t.Fatalf("expected prompt pre-filled with %s, got %q", autoRefreshDefaultInterval, got)
+	}
+
+	m.refreshIntervalInput.SetValue("30s adaptive")
+	_, cmd := m.handleAutoRefreshIntervalMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if cmd == nil {
+		t.Fatalf("expected a tick command after setting the interval")
+	}
+	if m.refreshIntervalEditing {
+		t.Fatalf("expected prompt to close after enter")
+	}
+	if !m.autoRefresh || m.autoRefreshInterval != 30*time.Second || !m.autoRefreshAdaptive {
+		t.Fatalf("unexpected settings: on=%v interval=%s adaptive=%v", m.autoRefresh, m.autoRefreshInterval, m.autoRefreshAdaptive)
+	}
+}
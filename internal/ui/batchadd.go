@@ -0,0 +1,299 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"charm.land/bubbles/v2/textarea"
+	"charm.land/bubbles/v2/textinput"
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"github.com/google/shlex"
+)
+
+// batchAddState holds the "L" batch add screen: shared modifiers, the
+// pasted list and the preview of the tasks about to be created.
+type batchAddState struct {
+	batchAddActive   bool
+	batchAddPreview  bool
+	batchAddFocus    int // batchFocusDefaults or batchFocusList
+	batchAddDefaults textinput.Model
+	batchAddList     textarea.Model
+	batchAddPlan     [][]string // "task add" arguments, one entry per task
+	batchAddViewport viewport.Model
+}
+
+const (
+	batchFocusDefaults = iota
+	batchFocusList
+)
+
+type batchAddEditLaunchMsg struct {
+	tempFile string
+	err      error
+}
+
+type batchAddEditDoneMsg struct {
+	tempFile string
+	err      error
+}
+
+// batchListMarker matches list bullets and Markdown checkboxes in front of
+// a pasted line: "- ", "* ", "+ ", "1. ", "2) " and "[ ] " / "[x] ".
+var batchListMarker = regexp.MustCompile(`^(?:[-*+]\s+|\d+[.)]\s+)?(\[[ xX]\]\s*)?`)
+
+// batchAddLines extracts one description per line. Blank lines, Markdown
+// headings and checked-off checklist items are skipped.
+func batchAddLines(text string) []string {
+	var lines []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		marker := batchListMarker.FindStringSubmatch(line)
+		if box := strings.TrimSpace(marker[1]); box == "[x]" || box == "[X]" {
+			continue
+		}
+		if line = strings.TrimSpace(line[len(marker[0]):]); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// batchAddArgs turns the shared modifiers and the list into one "task add"
+// argument list per task. Descriptions go after "--" so pasted notes that
+// happen to contain "due:" or "+word" are kept verbatim.
+func batchAddArgs(defaults, list string) ([][]string, error) {
+	mods, err := shlex.Split(defaults)
+	if err != nil {
+		return nil, fmt.Errorf("modifiers: %w", err)
+	}
+	lines := batchAddLines(list)
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("no tasks in the list")
+	}
+	plan := make([][]string, 0, len(lines))
+	for _, desc := range lines {
+		if err := validateDescription(desc); err != nil {
+			return nil, fmt.Errorf("%q: %w", desc, err)
+		}
+		args := append(append([]string(nil), mods...), "--", desc)
+		plan = append(plan, args)
+	}
+	return plan, nil
+}
+
+// handleBatchAdd opens the batch add screen.
+func (m *Model) handleBatchAdd() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	m.batchAddActive = true
+	m.batchAddPreview = false
+	m.batchAddPlan = nil
+	m.batchAddDefaults.SetValue("")
+	m.batchAddList.SetValue("")
+	m.focusBatchAdd(batchFocusList)
+	return m, nil
+}
+
+func (m *Model) focusBatchAdd(focus int) {
+	m.batchAddFocus = focus
+	if focus == batchFocusDefaults {
+		m.batchAddList.Blur()
+		m.batchAddDefaults.Focus()
+		return
+	}
+	m.batchAddDefaults.Blur()
+	m.batchAddList.Focus()
+}
+
+func (m *Model) closeBatchAdd() {
+	m.batchAddActive = false
+	m.batchAddPreview = false
+	m.batchAddPlan = nil
+	m.batchAddDefaults.Blur()
+	m.batchAddList.Blur()
+}
+
+// handleBatchAddMode handles keys on the batch add screen. While editing,
+// Tab switches between the modifiers and the list, Ctrl+O opens the list
+// in $EDITOR and Ctrl+S shows the preview. The preview creates the tasks
+// on y/Enter and returns to editing on n/Esc.
+func (m *Model) handleBatchAddMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.batchAddPreview {
+		switch msg.String() {
+		case "y", "enter":
+			return m.commitBatchAdd()
+		case "n", "esc", "q":
+			m.batchAddPreview = false
+			m.focusBatchAdd(m.batchAddFocus)
+		case "up", "k":
+			m.batchAddViewport.ScrollUp(1)
+		case "down", "j":
+			m.batchAddViewport.ScrollDown(1)
+		case "pgup", "b":
+			m.batchAddViewport.PageUp()
+		case "pgdown", "space":
+			m.batchAddViewport.PageDown()
+		}
+		return m, nil
+	}
+
+	switch msg.String() {
+	case "esc":
+		m.closeBatchAdd()
+		m.statusMsg = "Batch add cancelled"
+		return m, nil
+	case "tab", "shift+tab":
+		m.focusBatchAdd(1 - m.batchAddFocus)
+		return m, nil
+	case "ctrl+o":
+		content := m.batchAddList.Value()
+		return m, func() tea.Msg {
+			name, err := writeEditorTempFile("tasksamurai-batch-*.md", content)
+			return batchAddEditLaunchMsg{tempFile: name, err: err}
+		}
+	case "ctrl+s":
+		return m.previewBatchAdd()
+	case "enter":
+		if m.batchAddFocus == batchFocusDefaults {
+			m.focusBatchAdd(batchFocusList)
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	if m.batchAddFocus == batchFocusDefaults {
+		m.batchAddDefaults, cmd = m.batchAddDefaults.Update(msg)
+	} else {
+		m.batchAddList, cmd = m.batchAddList.Update(msg)
+	}
+	return m, cmd
+}
+
+// handleBatchAddPaste inserts pasted text into the focused field.
+func (m *Model) handleBatchAddPaste(msg tea.PasteMsg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.batchAddFocus == batchFocusDefaults {
+		m.batchAddDefaults, cmd = m.batchAddDefaults.Update(msg)
+	} else {
+		m.batchAddList, cmd = m.batchAddList.Update(msg)
+	}
+	return m, cmd
+}
+
+// launchBatchAddEditorCmd runs $EDITOR on the batch list buffer.
+func launchBatchAddEditorCmd(tempFile string) tea.Cmd {
+	return launchEditorCmd(tempFile, func(err error) tea.Msg {
+		return batchAddEditDoneMsg{tempFile: tempFile, err: err}
+	})
+}
+
+// handleBatchAddEditDone loads the edited buffer back into the list.
+func (m *Model) handleBatchAddEditDone(msg batchAddEditDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.tempFile != "" {
+		defer os.Remove(msg.tempFile)
+	}
+	if msg.err != nil {
+		m.showError(fmt.Errorf("opening editor: %w", msg.err))
+		return m, nil
+	}
+	data, err := os.ReadFile(msg.tempFile)
+	if err != nil {
+		m.showError(fmt.Errorf("reading edited list: %w", err))
+		return m, nil
+	}
+	m.batchAddList.SetValue(strings.TrimRight(string(data), "\n"))
+	m.focusBatchAdd(batchFocusList)
+	m.statusMsg = "Edited in $EDITOR — press Ctrl+S to preview"
+	return m, nil
+}
+
+func (m *Model) previewBatchAdd() (tea.Model, tea.Cmd) {
+	plan, err := batchAddArgs(m.batchAddDefaults.Value(), m.batchAddList.Value())
+	if err != nil {
+		return m, m.showErrorTimed(err)
+	}
+	m.batchAddPlan = plan
+	m.batchAddPreview = true
+	m.batchAddDefaults.Blur()
+	m.batchAddList.Blur()
+
+	var b strings.Builder
+	for i, args := range plan {
+		fmt.Fprintf(&b, "%3d. %s\n", i+1, strings.Join(args, " "))
+	}
+	m.batchAddViewport = viewport.New()
+	m.batchAddViewport.SetContent(strings.TrimRight(b.String(), "\n"))
+	return m, nil
+}
+
+// commitBatchAdd creates the previewed tasks, then selects the first one
+// and blinks all of them. It stops at the first failing "task add" so
+// nothing is created twice when the user retries.
+func (m *Model) commitBatchAdd() (tea.Model, tea.Cmd) {
+	plan := m.batchAddPlan
+	ctx, cancel := m.taskOperationContext()
+	created := 0
+	var err error
+	for _, args := range plan {
+		if err = m.taskwarriorClient().AddArgsContext(ctx, args); err != nil {
+			break
+		}
+		created++
+	}
+	cancel()
+
+	if err != nil && created == 0 {
+		m.batchAddPreview = false
+		m.focusBatchAdd(m.batchAddFocus)
+		return m, m.showErrorTimed(err)
+	}
+	m.closeBatchAdd()
+	diff, ok := m.reloadWithDiff()
+	if !ok {
+		return m, nil
+	}
+
+	uuids := make(map[string]bool, len(diff.added))
+	for _, uuid := range diff.added {
+		uuids[uuid] = true
+	}
+	if len(diff.added) > 0 {
+		if t := m.taskByUUID(diff.added[0]); t != nil {
+			m.selectTaskByID(t.ID)
+		}
+	}
+
+	if err != nil {
+		m.showError(fmt.Errorf("created %d of %d tasks: %w", created, len(plan), err))
+		return m, m.blinkUUIDs(uuids)
+	}
+	status := fmt.Sprintf("Created %d tasks", created)
+	if created == 1 {
+		status = "Created 1 task"
+	}
+	return m, tea.Batch(m.showStatusTimed(status), m.blinkUUIDs(uuids))
+}
+
+func (m *Model) renderBatchAddScreen() string {
+	width := m.fullScreenWidth()
+	if m.batchAddPreview {
+		m.batchAddViewport.SetWidth(width)
+		m.batchAddViewport.SetHeight(m.fullScreenRows())
+		return m.renderFullScreen(fmt.Sprintf("Batch add: create %d tasks?", len(m.batchAddPlan)),
+			[]string{m.batchAddViewport.View()},
+			"y/Enter create | n/Esc back | j/k scroll")
+	}
+
+	m.batchAddDefaults.SetWidth(width)
+	m.batchAddList.SetWidth(width)
+	m.batchAddList.SetHeight(max(m.fullScreenRows()-1, 1))
+	return m.renderFullScreen("Batch add: one task per line (bullets and [ ] checkboxes are stripped)",
+		[]string{m.batchAddDefaults.View(), m.batchAddList.View()},
+		"Tab switch field | Ctrl+O $EDITOR | Ctrl+S preview | Esc cancel")
+}
@@ -0,0 +1,180 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestBatchAddLines(t *testing.T) {
+	text := `# Meeting notes
+
+- [ ] Send slides
+- [x] Book room
+* Call Bob
+1. Draft budget
+2) Review +PR due:friday
+   plain line
+`
+	want := []string{"Send slides", "Call Bob", "Draft budget", "Review +PR due:friday", "plain line"}
+	if got := batchAddLines(text); !reflect.DeepEqual(got, want) {
+		t.Fatalf("batchAddLines = %q, want %q", got, want)
+	}
+}
+
+func TestBatchAddArgs(t *testing.T) {
+	plan, err := batchAddArgs(`project:work +meeting`, "- one\n- two")
+	if err != nil {
+		t.Fatalf("batchAddArgs: %v", err)
+	}
+	want := [][]string{
+		{"project:work", "+meeting", "--", "one"},
+		{"project:work", "+meeting", "--", "two"},
+	}
+	if !reflect.DeepEqual(plan, want) {
+		t.Fatalf("plan = %q, want %q", plan, want)
+	}
+
+	if _, err := batchAddArgs("", "\n# only a heading\n"); err == nil {
+		t.Fatalf("expected an error for an empty list")
+	}
+	if _, err := batchAddArgs(`project:"unterminated`, "- one"); err == nil {
+		t.Fatalf("expected an error for malformed modifiers")
+	}
+}
+
+// TestBatchAddCommit previews and creates a pasted list and checks that
+// every new row is highlighted and the first one selected.
+func TestBatchAddCommit(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "existing", Description: "Existing", Status: "pending"},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.blinkEnabled = true
+
+	m.handleBatchAdd()
+	if !m.batchAddActive || !m.anyInputActive() {
+		t.Fatalf("expected batch add screen to open")
+	}
+	m.batchAddDefaults.SetValue("+inbox")
+	m.handleBatchAddPaste(tea.PasteMsg{Content: "- [ ] First\n- [ ] Second"})
+	m.handleBatchAddMode(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	if !m.batchAddPreview || len(m.batchAddPlan) != 2 {
+		t.Fatalf("expected a preview of 2 tasks, got %q", m.batchAddPlan)
+	}
+
+	m.handleBatchAddMode(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	if m.batchAddActive {
+		t.Fatalf("expected batch add screen to close")
+	}
+	want := [][]string{{"+inbox", "--", "First"}, {"+inbox", "--", "Second"}}
+	if !reflect.DeepEqual(fake.addArgs, want) {
+		t.Fatalf("addArgs = %q, want %q", fake.addArgs, want)
+	}
+	if m.statusMsg != "Created 2 tasks" {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+	if !rowInverted(&m, "fake-2") || !rowInverted(&m, "fake-3") || rowInverted(&m, "existing") {
+		t.Fatalf("expected only the new rows to blink")
+	}
+	if sel, ok := m.selectedTask(); !ok || sel.UUID != "fake-2" {
+		t.Fatalf("expected the first new task to be selected, got %+v", sel)
+	}
+}
+
+// renumberingFake hands out task IDs in reverse, as Taskwarrior may
+// renumber the pending tasks after an add.
+type renumberingFake struct {
+	*fakeTaskwarrior
+}
+
+func (f *renumberingFake) Export(ctx context.Context, filters ...string) ([]task.Task, error) {
+	tasks, err := f.fakeTaskwarrior.Export(ctx, filters...)
+	for i := range tasks {
+		tasks[i].ID = len(tasks) - i
+	}
+	return tasks, err
+}
+
+// TestBatchAddCommitAfterRenumbering checks that the new tasks are found by
+// UUID when the IDs of the old ones changed.
+func TestBatchAddCommitAfterRenumbering(t *testing.T) {
+	fake := &renumberingFake{fakeTaskwarrior: &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "existing", Description: "Existing", Status: "pending"},
+	}}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.blinkEnabled = true
+	m.handleBatchAdd()
+	m.handleBatchAddPaste(tea.PasteMsg{Content: "First\nSecond"})
+	m.handleBatchAddMode(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	m.handleBatchAddMode(tea.KeyPressMsg{Code: 'y', Text: "y"})
+
+	if !rowInverted(&m, "fake-2") || !rowInverted(&m, "fake-3") || rowInverted(&m, "existing") {
+		t.Fatalf("expected only the new rows to blink")
+	}
+	// The reversed IDs list "Second" first.
+	if sel, ok := m.selectedTask(); !ok || sel.UUID != "fake-3" {
+		t.Fatalf("expected the first new row to be selected, got %+v", sel)
+	}
+}
+
+// TestBatchAddKeepsLongLists checks that a list longer than the textarea
+// default of 99 lines is not cut.
+func TestBatchAddKeepsLongLists(t *testing.T) {
+	m, err := NewWithTaskwarrior(nil, "firefox", &fakeTaskwarrior{})
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	lines := make([]string, 150)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("Task %d", i+1)
+	}
+	m.handleBatchAdd()
+	m.handleBatchAddPaste(tea.PasteMsg{Content: strings.Join(lines, "\n")})
+	m.handleBatchAddMode(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	if len(m.batchAddPlan) != len(lines) {
+		t.Fatalf("expected %d planned tasks, got %d", len(lines), len(m.batchAddPlan))
+	}
+}
+
+func TestBatchAddPreviewBack(t *testing.T) {
+	fake := &fakeTaskwarrior{}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.handleBatchAdd()
+	m.batchAddList.SetValue("one")
+	m.previewBatchAdd()
+	m.handleBatchAddMode(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if !m.batchAddActive || m.batchAddPreview {
+		t.Fatalf("expected Esc in the preview to return to editing")
+	}
+	m.handleBatchAddMode(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.batchAddActive || len(fake.addArgs) != 0 {
+		t.Fatalf("expected Esc to cancel without adding")
+	}
+}
+
+// rowInverted reports whether the table row of the task with uuid is drawn
+// in reverse video, as blinking rows are.
+func rowInverted(m *Model, uuid string) bool {
+	for r, row := range m.tbl.Rows() {
+		if i := m.taskIndexAtRow(r); i >= 0 && m.tasks[i].UUID == uuid {
+			return strings.Contains(strings.Join(row, ""), "\x1b[7m")
+		}
+	}
+	return false
+}
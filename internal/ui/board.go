@@ -0,0 +1,298 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/config"
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// boardState holds the "K" kanban board.
+type boardState struct {
+	boardActive bool
+	board       config.Board
+	boardLane   int // selected lane
+	boardRow    int // selected card within the lane
+}
+
+// boardCardHeight is the number of lines a card takes, including the gap.
+const boardCardHeight = 4
+
+// SetBoard sets the lanes of the kanban board. An empty board selects
+// config.DefaultBoard.
+func (m *Model) SetBoard(board config.Board) {
+	m.board = board
+}
+
+func (m *Model) boardConfig() config.Board {
+	if len(m.board.Lanes) == 0 {
+		return config.DefaultBoard
+	}
+	return m.board
+}
+
+// handleBoard opens the kanban board on the current task list.
+func (m *Model) handleBoard() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	m.boardLane, m.boardRow = 0, 0
+	if t, ok := m.selectedTask(); ok {
+		m.selectBoardCard(t.UUID)
+	}
+	m.boardActive = true
+	return m, nil
+}
+
+// boardLaneOf returns the lane index of t at now, or -1 when t is in no
+// lane.
+func boardLaneOf(board config.Board, t task.Task, now time.Time) int {
+	var value string
+	switch board.By {
+	case "status":
+		switch {
+		case t.Status == "completed":
+			value = t.Status
+		case t.Waiting(now):
+			value = "waiting"
+		case t.Start != "":
+			value = "started"
+		default:
+			value = t.Status
+		}
+	case "tag":
+		for i, lane := range board.Lanes {
+			if lane != "" && slices.Contains(t.Tags, lane) {
+				return i
+			}
+		}
+	case "uda":
+		value = t.UDA[board.UDA]
+		if board.UDA == "priority" {
+			value = t.Priority
+		}
+	}
+	return slices.Index(board.Lanes, value)
+}
+
+// boardLanes sorts the current tasks into the lanes, keeping their order.
+func (m *Model) boardLanes() [][]task.Task {
+	board := m.boardConfig()
+	lanes := make([][]task.Task, len(board.Lanes))
+	now := time.Now()
+	for _, t := range m.tasks {
+		if i := boardLaneOf(board, t, now); i >= 0 {
+			lanes[i] = append(lanes[i], t)
+		}
+	}
+	return lanes
+}
+
+// selectedBoardCard returns the task of the selected card.
+func (m *Model) selectedBoardCard() (task.Task, bool) {
+	lanes := m.boardLanes()
+	if m.boardLane < 0 || m.boardLane >= len(lanes) {
+		return task.Task{}, false
+	}
+	lane := lanes[m.boardLane]
+	if m.boardRow < 0 || m.boardRow >= len(lane) {
+		return task.Task{}, false
+	}
+	return lane[m.boardRow], true
+}
+
+// selectBoardCard moves the selection to the card of uuid, if it is shown.
+func (m *Model) selectBoardCard(uuid string) {
+	for i, lane := range m.boardLanes() {
+		for j, t := range lane {
+			if t.UUID == uuid {
+				m.boardLane, m.boardRow = i, j
+				return
+			}
+		}
+	}
+}
+
+// clampBoardRow keeps the selected card within the selected lane.
+func (m *Model) clampBoardRow() {
+	lanes := m.boardLanes()
+	if m.boardLane >= len(lanes) {
+		m.boardLane = max(len(lanes)-1, 0)
+	}
+	if len(lanes) == 0 {
+		m.boardRow = 0
+		return
+	}
+	m.boardRow = max(min(m.boardRow, len(lanes[m.boardLane])-1), 0)
+}
+
+// handleBoardMode handles keys on the kanban board. h/l select a lane, j/k a
+// card, and H/L move the selected card to the lane on the left or right.
+func (m *Model) handleBoardMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	lanes := len(m.boardConfig().Lanes)
+	switch msg.String() {
+	case "esc", "q", "K":
+		m.boardActive = false
+	case "left", "h":
+		m.boardLane = max(m.boardLane-1, 0)
+		m.clampBoardRow()
+	case "right", "l":
+		m.boardLane = min(m.boardLane+1, lanes-1)
+		m.clampBoardRow()
+	case "down", "j":
+		m.boardRow++
+		m.clampBoardRow()
+	case "up", "k":
+		m.boardRow = max(m.boardRow-1, 0)
+	case "shift+left", "H":
+		return m, m.moveBoardCard(m.boardLane - 1)
+	case "shift+right", "L":
+		return m, m.moveBoardCard(m.boardLane + 1)
+	}
+	return m, nil
+}
+
+// moveBoardCard moves the selected card to lane and reloads the tasks.
+func (m *Model) moveBoardCard(lane int) tea.Cmd {
+	board := m.boardConfig()
+	t, ok := m.selectedBoardCard()
+	if !ok || lane < 0 || lane >= len(board.Lanes) {
+		return nil
+	}
+	if err := m.applyBoardLane(board, t, board.Lanes[lane]); err != nil {
+		return m.showErrorTimed(err)
+	}
+	if !m.reloadAndReport() {
+		return nil
+	}
+	m.boardLane = lane
+	m.clampBoardRow()
+	m.selectBoardCard(t.UUID)
+	return m.showStatusTimed(fmt.Sprintf("Moved task %d to %s", t.ID, boardLaneTitle(board.Lanes[lane])))
+}
+
+// applyBoardLane runs the modification that puts t into the lane with the
+// given value: start/stop and wait for status lanes, a tag swap for tag
+// lanes and a UDA change for UDA lanes.
+func (m *Model) applyBoardLane(board config.Board, t task.Task, value string) error {
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	tw := m.taskwarriorClient()
+	switch board.By {
+	case "status":
+		if t.Start != "" && value != "started" {
+			if err := tw.StopContext(ctx, t.ID); err != nil {
+				return err
+			}
+		}
+		switch {
+		case value == "waiting":
+			return tw.ModifyUUIDContext(ctx, t.UUID, []string{"wait:" + seriesPauseWait})
+		case t.Waiting(time.Now()):
+			if err := tw.ModifyUUIDContext(ctx, t.UUID, []string{"wait:"}); err != nil {
+				return err
+			}
+		}
+		if value == "started" {
+			return tw.StartContext(ctx, t.ID)
+		}
+		return nil
+	case "tag":
+		var args []string
+		for _, lane := range board.Lanes {
+			if lane != "" && lane != value && slices.Contains(t.Tags, lane) {
+				args = append(args, "-"+lane)
+			}
+		}
+		if value != "" {
+			args = append(args, "+"+value)
+		}
+		return tw.ModifyUUIDContext(ctx, t.UUID, args)
+	case "uda":
+		return tw.ModifyUUIDContext(ctx, t.UUID, []string{board.UDA + ":" + value})
+	}
+	return nil
+}
+
+func boardLaneTitle(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+// renderBoardCard renders t as a card of the given width: the ID,
+// priority and urgency, the description and the project and tags, in the
+// style of the ultra mode cards.
+func (m *Model) renderBoardCard(t task.Task, width int, selected bool) string {
+	idStyle := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("253"))
+	if t.Start != "" {
+		idStyle = idStyle.Foreground(lipgloss.Color("0")).Background(lipgloss.Color(m.theme.UltraStartedBG))
+	}
+	head := []string{idStyle.Render(fmt.Sprintf("#%d", t.ID))}
+	if t.Priority != "" {
+		head = append(head, ultraPriorityStyle(m.theme, t.Priority).Render(t.Priority))
+	}
+	head = append(head, lipgloss.NewStyle().Foreground(lipgloss.Color("214")).Render(fmt.Sprintf("%.1f", t.Urgency)))
+
+	meta := ultraOrDash(t.Project)
+	if len(t.Tags) > 0 {
+		meta += " +" + strings.Join(t.Tags, " +")
+	}
+	line := lipgloss.NewStyle().MaxWidth(width)
+	card := strings.Join([]string{
+		line.Render(strings.Join(head, " ")),
+		line.Render(t.Description),
+		line.Foreground(lipgloss.Color("246")).Render(meta),
+	}, "\n")
+	return ultraCardStyle(m.theme, width, selected, false).Render(card)
+}
+
+func (m *Model) renderBoardScreen() string {
+	width := m.fullScreenWidth()
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.HeaderFG))
+
+	board := m.boardConfig()
+	lanes := m.boardLanes()
+	laneWidth := max((width-(len(lanes)-1))/max(len(lanes), 1), 1)
+	visible := max((m.fullScreenRows()-2)/boardCardHeight, 1)
+
+	columns := make([]string, len(lanes))
+	for i, lane := range lanes {
+		title := fmt.Sprintf("%s (%d)", boardLaneTitle(board.Lanes[i]), len(lane))
+		if i == m.boardLane {
+			title = "> " + title
+		}
+		cards := []string{header.MaxWidth(laneWidth).Render(title), ""}
+		offset := 0
+		if i == m.boardLane {
+			offset = max(m.boardRow-visible+1, 0)
+		}
+		for j := offset; j < len(lane) && j < offset+visible; j++ {
+			cards = append(cards, m.renderBoardCard(lane[j], laneWidth, i == m.boardLane && j == m.boardRow), "")
+		}
+		if hidden := len(lane) - offset - visible; hidden > 0 {
+			cards = append(cards, fmt.Sprintf("… %d more", hidden))
+		}
+		columns[i] = lipgloss.NewStyle().Width(laneWidth).Render(strings.Join(cards, "\n"))
+	}
+	var gapped []string
+	for i, column := range columns {
+		if i > 0 {
+			gapped = append(gapped, " ")
+		}
+		gapped = append(gapped, column)
+	}
+
+	title := fmt.Sprintf("Board by %s: %d tasks", board.By, len(m.tasks))
+	if board.By == "uda" {
+		title = fmt.Sprintf("Board by %s: %d tasks", board.UDA, len(m.tasks))
+	}
+	return m.renderFullScreen(title, []string{lipgloss.JoinHorizontal(lipgloss.Top, gapped...)},
+		"h/l lane | j/k card | H/L move card | Esc close")
+}
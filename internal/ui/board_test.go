@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/config"
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestBoardLaneOf(t *testing.T) {
+	status := config.DefaultBoard
+	tag := config.Board{By: "tag", Lanes: []string{"", "doing", "review"}}
+	uda := config.Board{By: "uda", UDA: "stage", Lanes: []string{"qa"}}
+	tests := []struct {
+		board config.Board
+		task  task.Task
+		want  int
+	}{
+		{status, task.Task{Status: "pending"}, 0},
+		{status, task.Task{Status: "pending", Start: "20260101T000000Z"}, 1},
+		{status, task.Task{Status: "waiting"}, 2},
+		{status, task.Task{Status: "pending", Wait: "20991231T000000Z"}, 2},
+		{status, task.Task{Status: "pending", Wait: "20000101T000000Z"}, 0},
+		{status, task.Task{Status: "completed"}, -1},
+		{tag, task.Task{Tags: []string{"home"}}, 0},
+		{tag, task.Task{Tags: []string{"review", "doing"}}, 1},
+		{uda, task.Task{UDA: map[string]string{"stage": "qa"}}, 0},
+		{uda, task.Task{}, -1},
+	}
+	for _, tt := range tests {
+		if got := boardLaneOf(tt.board, tt.task, time.Now()); got != tt.want {
+			t.Errorf("boardLaneOf(%+v, %+v) = %d, want %d", tt.board, tt.task, got, tt.want)
+		}
+	}
+}
+
+func TestBoardMoveCard(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "a", Description: "write docs", Status: "pending", Tags: []string{"todo", "docs"}},
+		{ID: 2, UUID: "b", Description: "fix bug", Status: "pending", Tags: []string{"doing"}},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.windowHeight = 20
+	m.SetBoard(config.Board{By: "tag", Lanes: []string{"todo", "doing", "review"}})
+	key := func(s string) {
+		t.Helper()
+		m.handleBoardMode(tea.KeyPressMsg{Code: rune(s[0]), Text: s})
+	}
+
+	m.handleBoard()
+	if !m.boardActive {
+		t.Fatal("expected the board to open")
+	}
+	screen := m.renderBoardScreen()
+	for _, want := range []string{"todo (1)", "doing (1)", "review (0)", "write docs", "fix bug"} {
+		if !strings.Contains(screen, want) {
+			t.Fatalf("board lacks %q:\n%s", want, screen)
+		}
+	}
+
+	m.boardLane, m.boardRow = 0, 0
+	key("L")
+	key("l")
+	key("L")
+	want := []string{"a -todo +doing", "b -doing +review"}
+	if !reflect.DeepEqual(fake.modifications, want) {
+		t.Fatalf("modifications = %q, want %q", fake.modifications, want)
+	}
+	if !strings.Contains(m.statusMsg, "Moved task 2 to review") {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+}
+
+// TestBoardMoveCardOutOfWaiting moves a task Taskwarrior still reports as
+// pending, but with a future wait date, out of the waiting lane.
+func TestBoardMoveCardOutOfWaiting(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "a", Description: "later", Status: "pending", Wait: "20991231T000000Z"},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.windowHeight = 20
+	m.SetBoard(config.Board{By: "status", Lanes: []string{"pending", "waiting"}})
+	m.handleBoard()
+	if m.boardLane != 1 {
+		t.Fatalf("expected the card in the waiting lane, got lane %d", m.boardLane)
+	}
+	m.handleBoardMode(tea.KeyPressMsg{Code: 'H', Text: "H"})
+	if want := []string{"a wait:"}; !reflect.DeepEqual(fake.modifications, want) {
+		t.Fatalf("modifications = %q, want %q", fake.modifications, want)
+	}
+}
@@ -0,0 +1,555 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// Bulk edit dumps the listed tasks into $EDITOR, one line per task, and
+// turns the edited buffer into modify/add/done/delete calls. Lines are
+//
+//	UUID | Pri | Project | Tags | Due | Description
+//
+// where a "+" (or empty) UUID creates a task and a removed line asks
+// whether the task is done, deleted, or kept.
+
+const bulkEditHeader = `# Task Samurai bulk edit: one task per line.
+#   UUID | Pri | Project | Tags | Due | Description
+# Edit fields to modify tasks. Use "+" as the UUID to add a task. Remove a
+# line to mark the task done or delete it (you will be asked which).
+# Lines starting with # are ignored. Save and quit to review the changes.
+`
+
+const bulkEditFields = 6
+
+// bulkPhase tracks which step of the bulk edit screen is shown.
+type bulkPhase int
+
+const (
+	bulkPhaseNone    bulkPhase = iota
+	bulkPhaseRemoved           // asking what to do with a removed line
+	bulkPhaseConfirm           // listing every planned change
+)
+
+type bulkRemovalAction int
+
+const (
+	bulkRemoveDone bulkRemovalAction = iota
+	bulkRemoveDelete
+	bulkRemoveKeep
+)
+
+// bulkEditState holds the bulk edit snapshot, plan and review screen.
+type bulkEditState struct {
+	bulkOriginal []task.Task
+	bulkPlan     bulkPlan
+	bulkPhase    bulkPhase
+	bulkRemoved  int // index into bulkPlan.removed being asked about
+	bulkViewport viewport.Model
+}
+
+// bulkLine is one parsed task line.
+type bulkLine struct {
+	uuid        string
+	priority    string
+	project     string
+	tags        []string
+	due         string
+	description string
+}
+
+type bulkModify struct {
+	task task.Task
+	args []string
+}
+
+type bulkRemoval struct {
+	task   task.Task
+	action bulkRemovalAction
+}
+
+// bulkPlan is the diff between the dumped and the edited buffer.
+type bulkPlan struct {
+	modify  []bulkModify
+	add     [][]string
+	removed []bulkRemoval
+}
+
+func (p bulkPlan) empty() bool {
+	return len(p.modify) == 0 && len(p.add) == 0 && len(p.removed) == 0
+}
+
+type bulkEditLaunchMsg struct {
+	tempFile string
+	err      error
+}
+
+type bulkEditDoneMsg struct {
+	tempFile string
+	err      error
+}
+
+// launchBulkEditorCmd suspends the TUI and runs $EDITOR on the bulk edit
+// buffer, emitting a bulkEditDoneMsg when it exits.
+func launchBulkEditorCmd(tempFile string) tea.Cmd {
+	return launchEditorCmd(tempFile, func(err error) tea.Msg {
+		return bulkEditDoneMsg{tempFile: tempFile, err: err}
+	})
+}
+
+// bulkDue renders a due date the way it appears in the bulk edit buffer:
+// a local date, with the time only when it is not midnight.
+func bulkDue(due string) string {
+	if due == "" {
+		return ""
+	}
+	ts, err := parseTaskDate(due)
+	if err != nil {
+		return due
+	}
+	ts = ts.In(time.Local)
+	if ts.Hour() == 0 && ts.Minute() == 0 && ts.Second() == 0 {
+		return ts.Format("2006-01-02")
+	}
+	return ts.Format("2006-01-02T15:04:05")
+}
+
+func bulkLineFromTask(t task.Task) bulkLine {
+	return bulkLine{
+		uuid:        t.UUID,
+		priority:    t.Priority,
+		project:     t.Project,
+		tags:        t.Tags,
+		due:         bulkDue(t.Due),
+		description: t.Description,
+	}
+}
+
+func (l bulkLine) String() string {
+	return strings.Join([]string{
+		l.uuid, l.priority, l.project, strings.Join(l.tags, " "), l.due, l.description,
+	}, " | ")
+}
+
+// formatBulkEdit renders tasks as the bulk edit buffer.
+func formatBulkEdit(tasks []task.Task) string {
+	var b strings.Builder
+	b.WriteString(bulkEditHeader)
+	for _, t := range tasks {
+		b.WriteString(bulkLineFromTask(t).String())
+		b.WriteByte('\n')
+	}
+	return b.String()
+}
+
+// parseBulkEdit parses the edited buffer. The description is the last
+// field, so it may itself contain "|".
+func parseBulkEdit(content string) ([]bulkLine, error) {
+	var lines []bulkLine
+	for n, raw := range strings.Split(content, "\n") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" || strings.HasPrefix(raw, "#") {
+			continue
+		}
+		fields := strings.SplitN(raw, "|", bulkEditFields)
+		if len(fields) != bulkEditFields {
+			return nil, fmt.Errorf("line %d: expected %d fields separated by |", n+1, bulkEditFields)
+		}
+		for i := range fields {
+			fields[i] = strings.TrimSpace(fields[i])
+		}
+		l := bulkLine{
+			uuid:        fields[0],
+			priority:    strings.ToUpper(fields[1]),
+			project:     fields[2],
+			tags:        bulkTags(fields[3]),
+			due:         fields[4],
+			description: fields[5],
+		}
+		if l.uuid == "+" {
+			l.uuid = ""
+		}
+		if err := l.validate(); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n+1, err)
+		}
+		lines = append(lines, l)
+	}
+	return lines, nil
+}
+
+// bulkTags splits the tags field. A leading "+" is optional, so "+foo" and
+// "foo" name the same tag.
+func bulkTags(field string) []string {
+	tags := strings.Fields(field)
+	for i, tag := range tags {
+		tags[i] = strings.TrimPrefix(tag, "+")
+	}
+	return tags
+}
+
+func (l bulkLine) validate() error {
+	if err := validateDescription(l.description); err != nil {
+		return err
+	}
+	if err := validatePriority(l.priority); err != nil {
+		return err
+	}
+	if strings.ContainsAny(l.project, " \t") {
+		return fmt.Errorf("project cannot contain whitespace")
+	}
+	for _, tag := range l.tags {
+		if err := validateTagName(tag); err != nil {
+			return err
+		}
+	}
+	return validateDueDate(l.due)
+}
+
+// planBulkEdit diffs the edited lines against the dumped tasks.
+func planBulkEdit(original []task.Task, lines []bulkLine) (bulkPlan, error) {
+	byUUID := make(map[string]task.Task, len(original))
+	for _, t := range original {
+		byUUID[t.UUID] = t
+	}
+
+	var plan bulkPlan
+	seen := make(map[string]bool, len(lines))
+	for _, l := range lines {
+		if l.uuid == "" {
+			plan.add = append(plan.add, bulkAddArgs(l))
+			continue
+		}
+		orig, ok := byUUID[l.uuid]
+		if !ok {
+			return bulkPlan{}, fmt.Errorf("unknown task UUID %s (use + to add a task)", l.uuid)
+		}
+		if seen[l.uuid] {
+			return bulkPlan{}, fmt.Errorf("task %s is listed twice", l.uuid)
+		}
+		seen[l.uuid] = true
+		if args := bulkModifyArgs(bulkLineFromTask(orig), l); len(args) > 0 {
+			plan.modify = append(plan.modify, bulkModify{task: orig, args: args})
+		}
+	}
+	for _, t := range original {
+		if !seen[t.UUID] {
+			plan.removed = append(plan.removed, bulkRemoval{task: t})
+		}
+	}
+	return plan, nil
+}
+
+func bulkAddArgs(l bulkLine) []string {
+	var args []string
+	if l.project != "" {
+		args = append(args, "project:"+l.project)
+	}
+	for _, tag := range l.tags {
+		args = append(args, "+"+tag)
+	}
+	if l.priority != "" {
+		args = append(args, "priority:"+l.priority)
+	}
+	if l.due != "" {
+		args = append(args, "due:"+l.due)
+	}
+	return append(args, "--", l.description)
+}
+
+// bulkModifyArgs returns the modifiers turning before into after. Fields
+// are compared as rendered in the buffer so untouched dates never produce
+// a modification.
+func bulkModifyArgs(before, after bulkLine) []string {
+	var args []string
+	if after.priority != before.priority {
+		args = append(args, "priority:"+after.priority)
+	}
+	if after.project != before.project {
+		args = append(args, "project:"+after.project)
+	}
+	for _, tag := range before.tags {
+		if !slices.Contains(after.tags, tag) {
+			args = append(args, "-"+tag)
+		}
+	}
+	for _, tag := range after.tags {
+		if !slices.Contains(before.tags, tag) {
+			args = append(args, "+"+tag)
+		}
+	}
+	if after.due != before.due {
+		args = append(args, "due:"+after.due)
+	}
+	if after.description != before.description {
+		args = append(args, "description:"+after.description)
+	}
+	return args
+}
+
+// handleBulkEdit dumps the listed tasks into $EDITOR.
+func (m *Model) handleBulkEdit() (tea.Model, tea.Cmd) {
+	if len(m.tasks) == 0 {
+		m.statusMsg = "No tasks to edit"
+		return m, nil
+	}
+	m.clearEditingModes()
+	m.bulkOriginal = append([]task.Task(nil), m.tasks...)
+	content := formatBulkEdit(m.bulkOriginal)
+	return m, func() tea.Msg {
+		name, err := writeEditorTempFile("tasksamurai-bulk-*.txt", content)
+		return bulkEditLaunchMsg{tempFile: name, err: err}
+	}
+}
+
+// handleBulkEditDone parses the edited buffer and opens the review screen.
+func (m *Model) handleBulkEditDone(msg bulkEditDoneMsg) (tea.Model, tea.Cmd) {
+	if msg.tempFile != "" {
+		defer os.Remove(msg.tempFile)
+	}
+	if msg.err != nil {
+		m.closeBulkEdit()
+		m.showError(fmt.Errorf("opening editor: %w", msg.err))
+		return m, nil
+	}
+	data, err := os.ReadFile(msg.tempFile)
+	if err != nil {
+		m.closeBulkEdit()
+		m.showError(fmt.Errorf("reading bulk edit: %w", err))
+		return m, nil
+	}
+	lines, err := parseBulkEdit(string(data))
+	if err == nil {
+		m.bulkPlan, err = planBulkEdit(m.bulkOriginal, lines)
+	}
+	if err != nil {
+		m.closeBulkEdit()
+		m.showError(fmt.Errorf("bulk edit: %w", err))
+		return m, nil
+	}
+	if m.bulkPlan.empty() {
+		m.closeBulkEdit()
+		m.statusMsg = "Bulk edit: no changes"
+		return m, nil
+	}
+
+	m.bulkViewport = viewport.New()
+	m.bulkRemoved = 0
+	if len(m.bulkPlan.removed) > 0 {
+		m.bulkPhase = bulkPhaseRemoved
+	} else {
+		m.bulkPhase = bulkPhaseConfirm
+	}
+	m.refreshBulkViewport()
+	return m, nil
+}
+
+// handleBulkEditMode handles keys on the bulk edit review screen.
+func (m *Model) handleBulkEditMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	key := msg.String()
+	if key == "esc" || key == "q" || (m.bulkPhase == bulkPhaseConfirm && key == "n") {
+		m.closeBulkEdit()
+		m.statusMsg = "Bulk edit cancelled"
+		return m, nil
+	}
+
+	if m.bulkPhase == bulkPhaseRemoved {
+		removal := &m.bulkPlan.removed[m.bulkRemoved]
+		switch key {
+		case "d":
+			removal.action = bulkRemoveDone
+		case "x":
+			removal.action = bulkRemoveDelete
+		case "k":
+			removal.action = bulkRemoveKeep
+		default:
+			return m, nil
+		}
+		m.bulkRemoved++
+		if m.bulkRemoved >= len(m.bulkPlan.removed) {
+			m.bulkPhase = bulkPhaseConfirm
+		}
+		m.refreshBulkViewport()
+		return m, nil
+	}
+
+	switch key {
+	case "y", "enter":
+		return m.applyBulkEdit()
+	case "up", "k":
+		m.bulkViewport.ScrollUp(1)
+	case "down", "j":
+		m.bulkViewport.ScrollDown(1)
+	case "pgup", "b":
+		m.bulkViewport.PageUp()
+	case "pgdown", "space":
+		m.bulkViewport.PageDown()
+	}
+	return m, nil
+}
+
+func (m *Model) closeBulkEdit() {
+	m.bulkPhase = bulkPhaseNone
+	m.bulkPlan = bulkPlan{}
+	m.bulkOriginal = nil
+}
+
+func bulkTaskLabel(t task.Task) string {
+	uuid := t.UUID
+	if len(uuid) > 8 {
+		uuid = uuid[:8]
+	}
+	return fmt.Sprintf("%s %q", uuid, t.Description)
+}
+
+// text lists every change that will be applied.
+func (p bulkPlan) text() string {
+	var b strings.Builder
+	for _, mod := range p.modify {
+		fmt.Fprintf(&b, "modify %s: %s\n", bulkTaskLabel(mod.task), strings.Join(mod.args, " "))
+	}
+	for _, args := range p.add {
+		fmt.Fprintf(&b, "add    %s\n", strings.Join(args, " "))
+	}
+	for _, r := range p.removed {
+		switch r.action {
+		case bulkRemoveDone:
+			fmt.Fprintf(&b, "done   %s\n", bulkTaskLabel(r.task))
+		case bulkRemoveDelete:
+			fmt.Fprintf(&b, "delete %s\n", bulkTaskLabel(r.task))
+		}
+	}
+	return b.String()
+}
+
+func (m *Model) refreshBulkViewport() {
+	var content string
+	if m.bulkPhase == bulkPhaseRemoved {
+		r := m.bulkPlan.removed[m.bulkRemoved]
+		content = fmt.Sprintf("This line was removed:\n\n  %s\n\nd = mark done, x = delete, k = keep", bulkLineFromTask(r.task))
+	} else {
+		content = m.bulkPlan.text()
+		if strings.TrimSpace(content) == "" {
+			content = "Nothing to apply (all removed tasks kept)."
+		}
+	}
+	m.bulkViewport.SetContent(strings.TrimRight(content, "\n"))
+}
+
+func (m *Model) renderBulkEditScreen() string {
+	m.bulkViewport.SetWidth(m.fullScreenWidth())
+	m.bulkViewport.SetHeight(m.fullScreenRows())
+
+	title, help := "Bulk edit: review changes", "y/Enter apply | n/Esc cancel | j/k scroll"
+	if m.bulkPhase == bulkPhaseRemoved {
+		title = fmt.Sprintf("Bulk edit: removed task %d of %d", m.bulkRemoved+1, len(m.bulkPlan.removed))
+		help = "d done | x delete | k keep | Esc cancel"
+	}
+	return m.renderFullScreen(title, []string{m.bulkViewport.View()}, help)
+}
+
+// fullScreenWidth is the width of the full-screen views.
+func (m *Model) fullScreenWidth() int {
+	if width := m.tbl.Width(); width > 0 {
+		return width
+	}
+	return 80
+}
+
+// fullScreenRows is the number of body lines between the title and the
+// footer of a full-screen view.
+func (m *Model) fullScreenRows() int {
+	return max(m.windowHeight-2, 1)
+}
+
+// renderFullScreen renders a full-screen view: the title bar, the body cut
+// or padded to fullScreenRows lines and the footer bar, which shows the
+// status message instead while there is one. Body entries may span several
+// lines.
+func (m *Model) renderFullScreen(title string, body []string, footer string) string {
+	bar := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.theme.StatusFG)).
+		Background(lipgloss.Color(m.theme.StatusBG)).
+		Width(m.fullScreenWidth())
+	rows := m.fullScreenRows()
+	lines := []string{bar.Render(title)}
+	if len(body) > 0 {
+		body = strings.Split(strings.Join(body, "\n"), "\n")
+		lines = append(lines, body[:min(len(body), rows)]...)
+	}
+	for len(lines) <= rows {
+		lines = append(lines, "")
+	}
+	if m.statusMsg != "" {
+		footer = m.statusMsg
+	}
+	lines = append(lines, bar.Render(footer))
+	return strings.Join(lines, "\n")
+}
+
+// applyBulkEdit runs the planned changes. It stops at the first failure so
+// the status line can say exactly how far it got; done and deleted tasks
+// are pushed as one undo step.
+func (m *Model) applyBulkEdit() (tea.Model, tea.Cmd) {
+	plan := m.bulkPlan
+	m.closeBulkEdit()
+
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	tw := m.taskwarriorClient()
+	applied := 0
+	var restores []undoRestore
+	err := func() error {
+		for _, mod := range plan.modify {
+			if err := tw.ModifyUUIDContext(ctx, mod.task.UUID, mod.args); err != nil {
+				return fmt.Errorf("modifying %s: %w", bulkTaskLabel(mod.task), err)
+			}
+			applied++
+		}
+		for _, args := range plan.add {
+			if err := tw.AddArgsContext(ctx, args); err != nil {
+				return fmt.Errorf("adding %q: %w", args[len(args)-1], err)
+			}
+			applied++
+		}
+		for _, r := range plan.removed {
+			status := "completed"
+			switch r.action {
+			case bulkRemoveKeep:
+				continue
+			case bulkRemoveDelete:
+				status = "deleted"
+			}
+			if err := tw.SetStatusUUIDContext(ctx, r.task.UUID, status); err != nil {
+				return fmt.Errorf("setting %s to %s: %w", bulkTaskLabel(r.task), status, err)
+			}
+			restores = append(restores, undoRestore{uuid: r.task.UUID, status: undoStatusForTask(r.task)})
+			applied++
+		}
+		return nil
+	}()
+	m.pushUndoAction("bulk edit", restores)
+
+	diff, ok := m.reloadWithDiff()
+	if err != nil {
+		m.showError(fmt.Errorf("bulk edit stopped after %d change(s): %w", applied, err))
+		return m, nil
+	}
+	if !ok {
+		return m, nil
+	}
+	uuids := make(map[string]bool, len(diff.added)+len(diff.changed))
+	for _, uuid := range slices.Concat(diff.added, diff.changed) {
+		uuids[uuid] = true
+	}
+	status := m.showStatusTimed(fmt.Sprintf("Bulk edit applied %d change(s)", applied))
+	return m, tea.Batch(status, m.blinkUUIDs(uuids))
+}
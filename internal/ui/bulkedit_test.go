@@ -0,0 +1,171 @@
+package ui
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestBulkEditRoundTrip(t *testing.T) {
+	tasks := []task.Task{
+		{UUID: "u1", Priority: "H", Project: "home", Tags: []string{"a", "b"}, Description: "Fix | sink"},
+		{UUID: "u2", Description: "Plain"},
+	}
+	lines, err := parseBulkEdit(formatBulkEdit(tasks))
+	if err != nil {
+		t.Fatalf("parseBulkEdit: %v", err)
+	}
+	plan, err := planBulkEdit(tasks, lines)
+	if err != nil {
+		t.Fatalf("planBulkEdit: %v", err)
+	}
+	if !plan.empty() {
+		t.Fatalf("expected an unchanged buffer to plan nothing, got %+v", plan)
+	}
+}
+
+func TestPlanBulkEdit(t *testing.T) {
+	tasks := []task.Task{
+		{UUID: "u1", Priority: "H", Project: "home", Tags: []string{"a", "b"}, Description: "Fix sink"},
+		{UUID: "u2", Description: "Gone"},
+	}
+	content := strings.Join([]string{
+		"# comment",
+		"u1 | l | garden | b c | 2026-01-02 | Fix the sink",
+		"+ | | work | x | | New task",
+		"",
+	}, "\n")
+	lines, err := parseBulkEdit(content)
+	if err != nil {
+		t.Fatalf("parseBulkEdit: %v", err)
+	}
+	plan, err := planBulkEdit(tasks, lines)
+	if err != nil {
+		t.Fatalf("planBulkEdit: %v", err)
+	}
+
+	wantMod := []string{"priority:L", "project:garden", "-a", "+c", "due:2026-01-02", "description:Fix the sink"}
+	if len(plan.modify) != 1 || !reflect.DeepEqual(plan.modify[0].args, wantMod) {
+		t.Fatalf("modify = %+v, want %q", plan.modify, wantMod)
+	}
+	wantAdd := []string{"project:work", "+x", "--", "New task"}
+	if len(plan.add) != 1 || !reflect.DeepEqual(plan.add[0], wantAdd) {
+		t.Fatalf("add = %q, want %q", plan.add, wantAdd)
+	}
+	if len(plan.removed) != 1 || plan.removed[0].task.UUID != "u2" {
+		t.Fatalf("removed = %+v, want u2", plan.removed)
+	}
+
+	// Writing an existing tag as +foo keeps it.
+	lines, err = parseBulkEdit("u1 | H | home | +a b | | Fix sink\nu2 | | | | | Gone")
+	if err != nil {
+		t.Fatalf("parseBulkEdit: %v", err)
+	}
+	if plan, err = planBulkEdit(tasks, lines); err != nil || len(plan.modify) != 0 {
+		t.Fatalf("expected no modifications for +a, got %+v (%v)", plan.modify, err)
+	}
+}
+
+func TestParseBulkEditErrors(t *testing.T) {
+	tasks := []task.Task{{UUID: "u1", Description: "One"}}
+	for name, content := range map[string]string{
+		"fields":    "u1 | H | Desc",
+		"priority":  "u1 | Z | | | | One",
+		"empty":     "u1 | | | | | ",
+		"unknown":   "u9 | | | | | One",
+		"duplicate": "u1 | | | | | One\nu1 | | | | | One",
+	} {
+		lines, err := parseBulkEdit(content)
+		if err == nil {
+			_, err = planBulkEdit(tasks, lines)
+		}
+		if err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+// TestBulkEditApply walks through the editor round trip: a removed line is
+// marked done, the confirmation screen lists every change, and confirming
+// applies them.
+func TestBulkEditApply(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "uuid-one", Description: "One", Status: "pending", Urgency: 2},
+		{ID: 2, UUID: "uuid-two", Description: "Two", Status: "pending", Urgency: 1},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+
+	_, cmd := m.handleBulkEdit()
+	launch, ok := cmd().(bulkEditLaunchMsg)
+	if !ok || launch.err != nil {
+		t.Fatalf("expected bulkEditLaunchMsg, got %+v", launch)
+	}
+	edited := "uuid-one | M | | | | One\n+ | | | | | Three\n"
+	if err := os.WriteFile(launch.tempFile, []byte(edited), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	m.handleBulkEditDone(bulkEditDoneMsg{tempFile: launch.tempFile})
+	if _, err := os.Stat(launch.tempFile); !os.IsNotExist(err) {
+		t.Fatalf("expected temp file to be removed")
+	}
+	if m.bulkPhase != bulkPhaseRemoved {
+		t.Fatalf("expected to be asked about the removed task, phase %d", m.bulkPhase)
+	}
+
+	m.handleBulkEditMode(tea.KeyPressMsg{Code: 'd', Text: "d"})
+	if m.bulkPhase != bulkPhaseConfirm {
+		t.Fatalf("expected confirmation screen, phase %d", m.bulkPhase)
+	}
+	screen := m.bulkPlan.text()
+	for _, want := range []string{"modify uuid-one", "priority:M", "add    -- Three", "done   uuid-two"} {
+		if !strings.Contains(screen, want) {
+			t.Fatalf("confirmation %q missing %q", screen, want)
+		}
+	}
+
+	m.handleBulkEditMode(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	if m.bulkPhase != bulkPhaseNone || m.anyInputActive() {
+		t.Fatalf("expected bulk edit to close")
+	}
+	if !reflect.DeepEqual(fake.modifications, []string{"uuid-one priority:M"}) {
+		t.Fatalf("modifications = %q", fake.modifications)
+	}
+	if len(fake.addArgs) != 1 || !reflect.DeepEqual(fake.addArgs[0], []string{"--", "Three"}) {
+		t.Fatalf("addArgs = %q", fake.addArgs)
+	}
+	if !reflect.DeepEqual(fake.statusChanges, []string{"uuid-two completed"}) {
+		t.Fatalf("statusChanges = %q", fake.statusChanges)
+	}
+	if len(m.undoStack) != 1 || m.undoStack[0].label != "bulk edit" {
+		t.Fatalf("expected a bulk edit undo entry, got %+v", m.undoStack)
+	}
+	if !strings.Contains(m.statusMsg, "3 change(s)") {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+}
+
+func TestBulkEditCancel(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "uuid-one", Description: "One", Status: "pending"},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.bulkOriginal = fake.tasks
+	m.bulkPlan = bulkPlan{modify: []bulkModify{{task: fake.tasks[0], args: []string{"priority:H"}}}}
+	m.bulkPhase = bulkPhaseConfirm
+
+	m.handleBulkEditMode(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.bulkPhase != bulkPhaseNone || len(fake.modifications) != 0 {
+		t.Fatalf("expected cancel to apply nothing")
+	}
+}
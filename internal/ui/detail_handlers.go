@@ -41,6 +41,10 @@ func (m *Model) handleTaskDetailMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 	}
 
+	if m.detailHistoryVisible {
+		return m.handleDetailHistoryMode(msg)
+	}
+
 	// Normal task detail view mode
 	switch msg.String() {
 	case "q":
@@ -85,6 +89,8 @@ func (m *Model) handleTaskDetailMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m.handleDetailUndo()
 	case "ctrl+r":
 		return m.handleDetailSetRecurringSeriesRecurrence()
+	case "h":
+		return m.handleDetailHistory()
 	case "i", "enter":
 		// Check if current field is editable
 		return m.handleDetailFieldEdit()
@@ -156,6 +162,7 @@ func (m *Model) handleDetailSetRecurringSeriesRecurrence() (tea.Model, tea.Cmd)
 func (m *Model) closeDetailView() {
 	m.showTaskDetail = false
 	m.clearCurrentTaskDetail()
+	m.closeDetailHistory()
 	m.detailSearching = false
 	m.detailSearchRegex = nil
 	m.detailSearchInput.SetValue("")
@@ -0,0 +1,100 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// taskDiff lists the UUIDs that differ between two task lists.
+type taskDiff struct {
+	added   []string
+	changed []string
+	removed []string
+}
+
+func (d taskDiff) empty() bool {
+	return len(d.added) == 0 && len(d.changed) == 0 && len(d.removed) == 0
+}
+
+// touched returns the added and changed tasks, the ones still listed.
+func (d taskDiff) touched() map[string]bool {
+	uuids := make(map[string]bool, len(d.added)+len(d.changed))
+	for _, uuid := range d.added {
+		uuids[uuid] = true
+	}
+	for _, uuid := range d.changed {
+		uuids[uuid] = true
+	}
+	return uuids
+}
+
+// summary renders the diff as e.g. "+2 new, 1 changed, 3 gone", leaving
+// out the parts that are zero.
+func (d taskDiff) summary() string {
+	var parts []string
+	if n := len(d.added); n > 0 {
+		parts = append(parts, fmt.Sprintf("+%d new", n))
+	}
+	if n := len(d.changed); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d changed", n))
+	}
+	if n := len(d.removed); n > 0 {
+		parts = append(parts, fmt.Sprintf("%d gone", n))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// diffTasks compares prev and next by UUID. IDs and urgency are ignored
+// because Taskwarrior renumbers IDs and recomputes urgency on every export
+// without the task itself having been modified. Tasks without a UUID cannot
+// be matched and are skipped.
+func diffTasks(prev, next []task.Task) taskDiff {
+	old := make(map[string]task.Task, len(prev))
+	for _, t := range prev {
+		if t.UUID != "" {
+			old[t.UUID] = t
+		}
+	}
+
+	var d taskDiff
+	seen := make(map[string]bool, len(next))
+	for _, t := range next {
+		if t.UUID == "" {
+			continue
+		}
+		seen[t.UUID] = true
+		before, ok := old[t.UUID]
+		switch {
+		case !ok:
+			d.added = append(d.added, t.UUID)
+		case !sameTaskContent(before, t):
+			d.changed = append(d.changed, t.UUID)
+		}
+	}
+	for _, t := range prev {
+		if t.UUID != "" && !seen[t.UUID] {
+			d.removed = append(d.removed, t.UUID)
+		}
+	}
+	return d
+}
+
+func sameTaskContent(a, b task.Task) bool {
+	a.ID, b.ID = 0, 0
+	a.Urgency, b.Urgency = 0, 0
+	return reflect.DeepEqual(a, b)
+}
+
+// reloadWithDiff reloads the task list and reports how it differs from the
+// list shown before. The boolean is false when the reload failed (the error
+// has already been shown).
+func (m *Model) reloadWithDiff() (taskDiff, bool) {
+	prev := m.tasks
+	if !m.reloadAndReport() {
+		return taskDiff{}, false
+	}
+	return diffTasks(prev, m.tasks), true
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"testing"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// TestDiffTasks checks that tasks are matched by UUID and that ID
+// renumbering and urgency drift are not reported as changes.
+func TestDiffTasks(t *testing.T) {
+	prev := []task.Task{
+		{ID: 1, UUID: "a", Description: "keep", Urgency: 1},
+		{ID: 2, UUID: "b", Description: "edit me"},
+		{ID: 3, UUID: "c", Description: "finish me"},
+	}
+	next := []task.Task{
+		{ID: 2, UUID: "a", Description: "keep", Urgency: 2.5},
+		{ID: 1, UUID: "b", Description: "edited"},
+		{ID: 3, UUID: "d", Description: "new"},
+		{ID: 4, UUID: "e", Description: "newer"},
+	}
+
+	d := diffTasks(prev, next)
+	if len(d.added) != 2 || len(d.changed) != 1 || d.changed[0] != "b" || len(d.removed) != 1 || d.removed[0] != "c" {
+		t.Fatalf("unexpected diff: %+v", d)
+	}
+	if got, want := d.summary(), "+2 new, 1 changed, 1 gone"; got != want {
+		t.Fatalf("summary = %q, want %q", got, want)
+	}
+	if !diffTasks(prev, prev).empty() {
+		t.Fatalf("expected identical lists to produce an empty diff")
+	}
+}
+
+// TestRefreshHighlightsChangedTasks verifies that a manual refresh reports the
+// diff, blinks the affected rows without blocking input, and stops after
+// blinkCycles ticks.
+func TestRefreshHighlightsChangedTasks(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "a", Description: "one", Status: "pending"},
+		{ID: 2, UUID: "b", Description: "two", Status: "pending"},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+
+	_, _ = m.handleRefresh()
+	if m.statusMsg != "No changes" {
+		t.Fatalf("expected no-changes status, got %q", m.statusMsg)
+	}
+
+	fake.tasks = []task.Task{
+		{ID: 1, UUID: "a", Description: "one (edited)", Status: "pending"},
+		{ID: 2, UUID: "c", Description: "three", Status: "pending"},
+	}
+	_, cmd := m.handleRefresh()
+	if cmd == nil {
+		t.Fatalf("expected highlight command")
+	}
+	if m.statusMsg != "+1 new, 1 changed, 1 gone" {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+	if m.blinkID != 0 {
+		t.Fatalf("diff highlight must not use the input-blocking row blink")
+	}
+	if !m.blinking(m.tasks[0]) || !m.blinking(m.tasks[1]) {
+		t.Fatalf("expected both rows to be highlighted")
+	}
+
+	for i := 0; i < blinkCycles; i++ {
+		m.handleBlinkMsg()
+	}
+	if m.blinkOn || m.blinkTasks != nil {
+		t.Fatalf("expected highlight to stop after %d cycles", blinkCycles)
+	}
+	if _, cmd := m.handleBlinkMsg(); cmd != nil {
+		t.Fatalf("expected a tick after the highlight to be dropped")
+	}
+}
+
+// TestBlinkUUIDsKeepsOneTickLoop verifies that a highlight started while
+// another runs reuses the pending tick instead of starting a second loop.
+func TestBlinkUUIDsKeepsOneTickLoop(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "a", Description: "one", Status: "pending"},
+		{ID: 2, UUID: "b", Description: "two", Status: "pending"},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+
+	if cmd := m.blinkUUIDs(map[string]bool{"a": true}); cmd == nil {
+		t.Fatal("expected the first highlight to schedule a tick")
+	}
+	if cmd := m.blinkUUIDs(map[string]bool{"b": true}); cmd != nil {
+		t.Fatal("expected the second highlight to reuse the pending tick")
+	}
+	if m.blinking(m.tasks[0]) || !m.blinking(m.tasks[1]) {
+		t.Fatal("expected only the second highlight to show")
+	}
+	if _, cmd := m.handleBlinkMsg(); cmd == nil {
+		t.Fatal("expected the tick loop to go on")
+	}
+}
+
+// TestRefreshDiffRespectsBlinkToggle verifies that with blinking disabled only
+// the summary is shown.
+func TestRefreshDiffRespectsBlinkToggle(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{{ID: 1, UUID: "a", Description: "one", Status: "pending"}}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.blinkEnabled = false
+
+	fake.tasks = append(fake.tasks, task.Task{ID: 2, UUID: "b", Description: "two", Status: "pending"})
+	m.handleRefresh()
+	if m.statusMsg != "+1 new" {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+	if m.blinkOn {
+		t.Fatalf("expected no highlight while blinking is disabled")
+	}
+}
+
+// TestDetailAndRowBlinkShareTicks verifies that a detail view blink and a
+// row highlight running together advance on one tick loop.
+func TestDetailAndRowBlinkShareTicks(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{{ID: 1, UUID: "a", Description: "one", Status: "pending"}}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.showTaskDetail = true
+
+	if cmd := m.startDetailBlink(0); cmd == nil {
+		t.Fatal("expected the detail blink to schedule a tick")
+	}
+	if cmd := m.blinkUUIDs(map[string]bool{"a": true}); cmd != nil {
+		t.Fatal("expected the row highlight to reuse the pending tick")
+	}
+	if _, cmd := m.handleBlinkMsg(); cmd == nil || m.detailBlinking() {
+		t.Fatal("expected the detail blink to end and the tick loop to go on")
+	}
+	if m.blinkCount != 1 {
+		t.Fatalf("expected the tick to advance the row highlight, got %d cycles", m.blinkCount)
+	}
+	for i := 1; i < blinkCycles; i++ {
+		m.handleBlinkMsg()
+	}
+	if m.blinkTasks != nil || m.blinkTicking {
+		t.Fatal("expected the highlight and the tick loop to end")
+	}
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/config"
+)
+
+// enrichState holds the rules that suggest modifiers in the add prompt.
+// Tab appends the first suggestion to the add line, shift+tab rejects it
+// for the rest of the prompt.
+type enrichState struct {
+	enrichRules    []enrichRule
+	enrichRejected map[int]bool // rules rejected in the open add prompt
+}
+
+type enrichRule struct {
+	name string
+	re   *regexp.Regexp
+	add  string
+}
+
+// enrichSuggestion is a rule matching the add line, with its modifiers
+// expanded.
+type enrichSuggestion struct {
+	rule int
+	name string
+	mods string
+}
+
+// SetEnrichRules sets the add prompt suggestion rules. If a match does not
+// compile, the error is returned and the rules set before are kept.
+func (m *Model) SetEnrichRules(rules []config.EnrichRule) error {
+	var compiled []enrichRule
+	for i, r := range rules {
+		re, err := regexp.Compile(r.Match)
+		if err != nil {
+			return fmt.Errorf("enrich rule %d: %w", i+1, err)
+		}
+		name := r.Name
+		if name == "" {
+			name = r.Add
+		}
+		compiled = append(compiled, enrichRule{name: name, re: re, add: r.Add})
+	}
+	m.enrichRules = compiled
+	return nil
+}
+
+// enrichSuggestions returns the rules matching line that were neither
+// rejected nor already applied, i.e. whose modifiers are not all on the
+// line yet.
+func (m *Model) enrichSuggestions(line string) []enrichSuggestion {
+	fields := strings.Fields(line)
+	var suggestions []enrichSuggestion
+	for i, r := range m.enrichRules {
+		if m.enrichRejected[i] {
+			continue
+		}
+		match := r.re.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+		mods := strings.Join(strings.Fields(string(r.re.ExpandString(nil, r.add, line, match))), " ")
+		if mods == "" || !slices.ContainsFunc(strings.Fields(mods), func(mod string) bool {
+			return !slices.Contains(fields, mod)
+		}) {
+			continue
+		}
+		suggestions = append(suggestions, enrichSuggestion{rule: i, name: r.name, mods: mods})
+	}
+	return suggestions
+}
+
+// acceptEnrichSuggestion appends the first suggestion to the add line.
+func (m *Model) acceptEnrichSuggestion() bool {
+	line := m.addInput.Value()
+	suggestions := m.enrichSuggestions(line)
+	if len(suggestions) == 0 {
+		return false
+	}
+	m.addInput.SetValue(strings.TrimRight(line, " ") + " " + suggestions[0].mods)
+	m.addInput.CursorEnd()
+	return true
+}
+
+// rejectEnrichSuggestion hides the first suggestion until the add prompt
+// closes.
+func (m *Model) rejectEnrichSuggestion() bool {
+	suggestions := m.enrichSuggestions(m.addInput.Value())
+	if len(suggestions) == 0 {
+		return false
+	}
+	if m.enrichRejected == nil {
+		m.enrichRejected = make(map[int]bool)
+	}
+	m.enrichRejected[suggestions[0].rule] = true
+	return true
+}
+
+// addView renders the add prompt with the first pending suggestion and,
+// with quick add on, the resulting command below it.
+func (m *Model) addView() string {
+	view := m.addInput.View()
+	if hint := m.enrichHint(); hint != "" {
+		view += "  " + hint
+	}
+	if m.quickAdd {
+		view += "\n" + m.quickAddPreview()
+	}
+	return view
+}
+
+// enrichHint describes the first pending suggestion, if any.
+func (m *Model) enrichHint() string {
+	suggestions := m.enrichSuggestions(m.addInput.Value())
+	if len(suggestions) == 0 {
+		return ""
+	}
+	hint := "suggest " + suggestions[0].mods
+	if suggestions[0].name != suggestions[0].mods {
+		hint += " (" + suggestions[0].name + ")"
+	}
+	if more := len(suggestions) - 1; more > 0 {
+		hint += fmt.Sprintf(", %d more", more)
+	}
+	hint += "  tab accept, shift+tab reject"
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(hint)
+}
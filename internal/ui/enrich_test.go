@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/config"
+)
+
+func TestEnrichSuggestions(t *testing.T) {
+	fake := &fakeTaskwarrior{}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	if err := m.SetEnrichRules([]config.EnrichRule{
+		{Name: "GitHub link", Match: `https://github\.com/`, Add: "+review"},
+		{Match: `(?i)\bcall\b`, Add: "+phone"},
+		{Name: "file project", Match: `@(?:\./)?([^/\s]+)/`, Add: "project:$1"},
+	}); err != nil {
+		t.Fatalf("SetEnrichRules: %v", err)
+	}
+	if err := m.SetEnrichRules([]config.EnrichRule{{Match: "(", Add: "+x"}}); err == nil || len(m.enrichRules) != 3 {
+		t.Fatalf("expected an invalid match to fail and keep the rules, got %v and %d rules", err, len(m.enrichRules))
+	}
+
+	m.handleAddTask()
+	m.addInput.SetValue("Call Bob about @infra/dns.md and https://github.com/x/y/pull/1")
+	var mods []string
+	for _, s := range m.enrichSuggestions(m.addInput.Value()) {
+		mods = append(mods, s.mods)
+	}
+	if want := []string{"+review", "+phone", "project:infra"}; !reflect.DeepEqual(mods, want) {
+		t.Fatalf("suggestions = %q, want %q", mods, want)
+	}
+	if view := m.addView(); !strings.Contains(view, "suggest +review (GitHub link), 2 more") {
+		t.Fatalf("add prompt lacks the suggestion: %q", view)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab, Mod: tea.ModShift})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if len(m.enrichSuggestions(m.addInput.Value())) != 0 {
+		t.Fatal("expected every suggestion to be accepted or rejected")
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	want := "Call Bob about @infra/dns.md and https://github.com/x/y/pull/1 +review project:infra"
+	if len(fake.addLines) != 1 || fake.addLines[0] != want {
+		t.Fatalf("added %q, want %q", fake.addLines, want)
+	}
+	if m.enrichRejected != nil {
+		t.Fatal("expected the rejections to end with the prompt")
+	}
+}
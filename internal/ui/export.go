@@ -0,0 +1,324 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"image/color"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// exportFormats maps file extensions to the writers used by the "X"
+// export action.
+var exportFormats = map[string]func(m *Model, now time.Time) ([]byte, error){
+	".md":       (*Model).exportMarkdown,
+	".markdown": (*Model).exportMarkdown,
+	".csv":      (*Model).exportCSV,
+	".json":     (*Model).exportJSON,
+	".html":     (*Model).exportHTML,
+	".htm":      (*Model).exportHTML,
+	".ics":      (*Model).exportICS,
+}
+
+// handleExport opens the export file prompt.
+func (m *Model) handleExport() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	m.exportPathEditing = true
+	m.exportPathInput.SetValue("")
+	m.exportPathInput.Focus()
+	m.updateTableHeight()
+	m.statusMsg = "Export format from extension: .md, .csv, .json, .html or .ics"
+	return m, nil
+}
+
+// handleExportPathMode writes the listed tasks to the entered file.
+func (m *Model) handleExportPathMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	var status string
+	onEnter := func(value string) error {
+		path := expandHome(strings.TrimSpace(value))
+		if path == "" {
+			return fmt.Errorf("file name cannot be empty")
+		}
+		write, ok := exportFormats[strings.ToLower(filepath.Ext(path))]
+		if !ok {
+			return fmt.Errorf("unknown export format %q (use .md, .csv, .json, .html or .ics)", filepath.Ext(path))
+		}
+		data, err := write(m, time.Now())
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return err
+		}
+		status = fmt.Sprintf("Exported %d tasks to %s", len(m.tasks), path)
+		return nil
+	}
+
+	onExit := func() {
+		m.exportPathEditing = false
+	}
+
+	model, cmd := m.handleTextInput(msg, &m.exportPathInput, onEnter, onExit)
+	if status != "" {
+		return model, m.showStatusTimed(status)
+	}
+	return model, cmd
+}
+
+// exportColumns returns the titles of the active table columns.
+func (m *Model) exportColumns() []string {
+	active := m.activeColumns()
+	titles := make([]string, len(active))
+	for i, c := range active {
+		titles[i], _ = m.columnSpec(c)
+	}
+	return titles
+}
+
+// exportRow renders the active columns of t as plain text. Dates are
+// written as local ISO dates so reports stay readable after today.
+func (m *Model) exportRow(t task.Task) []string {
+	active := m.activeColumns()
+	row := make([]string, len(active))
+	for i, c := range active {
+		switch c {
+		case colPri:
+			row[i] = t.Priority
+		case colID:
+			row[i] = strconv.Itoa(t.ID)
+		case colAge:
+			row[i], _ = taskAgeText(t.Entry)
+		case colDue:
+			if ts, err := parseTaskDate(t.Due); err == nil {
+				row[i] = ts.Local().Format("2006-01-02")
+			}
+		case colRecur:
+			row[i] = t.Recur
+		case colProject:
+			row[i] = t.Project
+		case colTags:
+			row[i] = strings.Join(t.Tags, " ")
+		case colAnnotations:
+			anns := make([]string, 0, len(t.Annotations))
+			for _, a := range t.Annotations {
+				anns = append(anns, a.Description)
+			}
+			row[i] = strings.Join(anns, "; ")
+		case colDescription:
+			row[i] = t.Description
+		case colUrgency:
+			row[i] = fmt.Sprintf("%.1f", t.Urgency)
+		}
+	}
+	return row
+}
+
+func (m *Model) exportMarkdown(time.Time) ([]byte, error) {
+	cell := func(s string) string {
+		s = strings.ReplaceAll(s, "|", `\|`)
+		return strings.ReplaceAll(s, "\n", " ")
+	}
+	var b bytes.Buffer
+	writeRow := func(cells []string) {
+		b.WriteString("|")
+		for _, c := range cells {
+			b.WriteString(" " + cell(c) + " |")
+		}
+		b.WriteString("\n")
+	}
+	cols := m.exportColumns()
+	writeRow(cols)
+	b.WriteString("|" + strings.Repeat(" --- |", len(cols)) + "\n")
+	for _, t := range m.tasks {
+		writeRow(m.exportRow(t))
+	}
+	return b.Bytes(), nil
+}
+
+func (m *Model) exportCSV(time.Time) ([]byte, error) {
+	var b bytes.Buffer
+	w := csv.NewWriter(&b)
+	if err := w.Write(m.exportColumns()); err != nil {
+		return nil, err
+	}
+	for _, t := range m.tasks {
+		if err := w.Write(m.exportRow(t)); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	return b.Bytes(), w.Error()
+}
+
+// exportJSON writes one object per task, keyed by the lower-cased column
+// titles, keeping the table order.
+func (m *Model) exportJSON(time.Time) ([]byte, error) {
+	cols := m.exportColumns()
+	var b bytes.Buffer
+	b.WriteString("[")
+	for i, t := range m.tasks {
+		if i > 0 {
+			b.WriteString(",")
+		}
+		b.WriteString("\n  {")
+		for j, value := range m.exportRow(t) {
+			if j > 0 {
+				b.WriteString(", ")
+			}
+			key, _ := json.Marshal(strings.ToLower(cols[j]))
+			val, _ := json.Marshal(value)
+			b.Write(key)
+			b.WriteString(": ")
+			b.Write(val)
+		}
+		b.WriteString("}")
+	}
+	b.WriteString("\n]\n")
+	return b.Bytes(), nil
+}
+
+// cssColor converts a theme color (ANSI 256 index or hex) to CSS hex.
+func cssColor(c string) string {
+	var rgb color.Color = lipgloss.Color(c)
+	r, g, b, _ := rgb.RGBA()
+	return fmt.Sprintf("#%02x%02x%02x", r>>8, g>>8, b>>8)
+}
+
+// exportHTML writes a self-contained page whose colors follow the current
+// theme: the header and status colors, started rows and priority cells.
+func (m *Model) exportHTML(now time.Time) ([]byte, error) {
+	th := m.theme
+	var b bytes.Buffer
+	b.WriteString("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	fmt.Fprintf(&b, "<title>Task Samurai export %s</title>\n", now.Format("2006-01-02"))
+	fmt.Fprintf(&b, `<style>
+body { font-family: sans-serif; margin: 2em; }
+h1 { background: %s; color: %s; padding: .3em .6em; font-size: 1.2em; }
+table { border-collapse: collapse; width: 100%%; }
+th { color: %s; text-align: left; border-bottom: 2px solid %s; }
+th, td { padding: .25em .6em; vertical-align: top; }
+tr:nth-child(even) { background: #f4f4f4; }
+tr.started { background: %s; }
+td.pri-H { background: %s; color: #fff; }
+td.pri-M { background: %s; color: #fff; }
+td.pri-L { background: %s; color: #fff; }
+</style>
+</head>
+<body>
+`, cssColor(th.StatusBG), cssColor(th.StatusFG), cssColor(th.HeaderFG), cssColor(th.HeaderFG),
+		cssColor(th.StartBG), cssColor(th.PrioHighBG), cssColor(th.PrioMedBG), cssColor(th.PrioLowBG))
+	fmt.Fprintf(&b, "<h1>Tasks (%d) &mdash; %s</h1>\n<table>\n<tr>", len(m.tasks), html.EscapeString(now.Format("2006-01-02 15:04")))
+	for _, col := range m.exportColumns() {
+		fmt.Fprintf(&b, "<th>%s</th>", html.EscapeString(col))
+	}
+	b.WriteString("</tr>\n")
+	active := m.activeColumns()
+	for _, t := range m.tasks {
+		if t.Start != "" {
+			b.WriteString(`<tr class="started">`)
+		} else {
+			b.WriteString("<tr>")
+		}
+		for i, value := range m.exportRow(t) {
+			if active[i] == colPri && value != "" {
+				fmt.Fprintf(&b, `<td class="pri-%s">%s</td>`, html.EscapeString(value), html.EscapeString(value))
+				continue
+			}
+			fmt.Fprintf(&b, "<td>%s</td>", html.EscapeString(value))
+		}
+		b.WriteString("</tr>\n")
+	}
+	b.WriteString("</table>\n</body>\n</html>\n")
+	return b.Bytes(), nil
+}
+
+// icsText escapes a value for an iCalendar text property (RFC 5545 3.3.11).
+func icsText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// icsFold folds a content line to 75 octets, never splitting a UTF-8
+// sequence (RFC 5545 3.1).
+func icsFold(line string) string {
+	var b strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		b.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // the leading space counts towards the next line
+	}
+	b.WriteString(line + "\r\n")
+	return b.String()
+}
+
+// exportICS writes every listed task as a VTODO. Calendar fields are fixed
+// by the format rather than the active columns: summary, due date,
+// priority (H=1, M=5, L=9), tags as categories and the project.
+func (m *Model) exportICS(now time.Time) ([]byte, error) {
+	const stamp = "20060102T150405Z"
+	var b strings.Builder
+	line := func(format string, args ...any) {
+		b.WriteString(icsFold(fmt.Sprintf(format, args...)))
+	}
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//Task Samurai//EN")
+	for _, t := range m.tasks {
+		line("BEGIN:VTODO")
+		uid := t.UUID
+		if uid == "" {
+			uid = strconv.Itoa(t.ID)
+		}
+		line("UID:%s@tasksamurai", uid)
+		line("DTSTAMP:%s", now.UTC().Format(stamp))
+		line("SUMMARY:%s", icsText(t.Description))
+		if ts, err := parseTaskDate(t.Due); err == nil {
+			line("DUE:%s", ts.UTC().Format(stamp))
+		}
+		if ts, err := parseTaskDate(t.Entry); err == nil {
+			line("CREATED:%s", ts.UTC().Format(stamp))
+		}
+		switch t.Priority {
+		case "H":
+			line("PRIORITY:1")
+		case "M":
+			line("PRIORITY:5")
+		case "L":
+			line("PRIORITY:9")
+		}
+		if len(t.Tags) > 0 {
+			tags := make([]string, len(t.Tags))
+			for i, tag := range t.Tags {
+				tags[i] = icsText(tag)
+			}
+			line("CATEGORIES:%s", strings.Join(tags, ","))
+		}
+		if t.Project != "" {
+			line("X-TASKWARRIOR-PROJECT:%s", icsText(t.Project))
+		}
+		if t.Start != "" {
+			line("STATUS:IN-PROCESS")
+		} else {
+			line("STATUS:NEEDS-ACTION")
+		}
+		line("END:VTODO")
+	}
+	line("END:VCALENDAR")
+	return []byte(b.String()), nil
+}
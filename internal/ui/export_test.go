@@ -0,0 +1,105 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func newExportModel(t *testing.T) *Model {
+	t.Helper()
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "uuid-1", Description: "Fix | pipe, comma", Project: "dev", Tags: []string{"bug"}, Priority: "H", Due: "20260301T120000Z", Status: "pending", Urgency: 9},
+		{ID: 2, UUID: "uuid-2", Description: "Read <book>", Status: "pending", Start: "20260101T000000Z", Urgency: 1},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.compactView = true
+	return &m
+}
+
+func TestExportFormats(t *testing.T) {
+	m := newExportModel(t)
+	now := time.Date(2026, 2, 1, 8, 0, 0, 0, time.UTC)
+
+	md, _ := m.exportMarkdown(now)
+	wantMD := "| Pri | Project | Description | Urg |\n| --- | --- | --- | --- |\n| H | dev | Fix \\| pipe, comma | 9.0 |\n"
+	if !strings.HasPrefix(string(md), wantMD) {
+		t.Fatalf("markdown =\n%s", md)
+	}
+
+	csvData, err := m.exportCSV(now)
+	if err != nil || !strings.Contains(string(csvData), `H,dev,"Fix | pipe, comma",9.0`) {
+		t.Fatalf("csv = %s (%v)", csvData, err)
+	}
+
+	jsonData, _ := m.exportJSON(now)
+	var rows []map[string]string
+	if err := json.Unmarshal(jsonData, &rows); err != nil {
+		t.Fatalf("json: %v\n%s", err, jsonData)
+	}
+	if len(rows) != 2 || rows[0]["description"] != "Fix | pipe, comma" || rows[1]["urg"] != "1.0" {
+		t.Fatalf("json rows = %v", rows)
+	}
+
+	page, _ := m.exportHTML(now)
+	for _, want := range []string{"<th>Project</th>", "Read &lt;book&gt;", `<td class="pri-H">H</td>`, `<tr class="started">`, cssColor(m.theme.PrioHighBG)} {
+		if !strings.Contains(string(page), want) {
+			t.Fatalf("html missing %q:\n%s", want, page)
+		}
+	}
+
+	ics, _ := m.exportICS(now)
+	for _, want := range []string{"BEGIN:VTODO\r\n", "UID:uuid-1@tasksamurai\r\n", `SUMMARY:Fix | pipe\, comma`, "DUE:20260301T120000Z\r\n", "PRIORITY:1\r\n", "STATUS:IN-PROCESS\r\n"} {
+		if !strings.Contains(string(ics), want) {
+			t.Fatalf("ics missing %q:\n%s", want, ics)
+		}
+	}
+}
+
+func TestICSFold(t *testing.T) {
+	long := "SUMMARY:" + strings.Repeat("ä", 60)
+	folded := icsFold(long)
+	for _, line := range strings.Split(strings.TrimSuffix(folded, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Fatalf("line longer than 75 octets: %q", line)
+		}
+	}
+	if unfolded := strings.ReplaceAll(folded, "\r\n ", ""); unfolded != long+"\r\n" {
+		t.Fatalf("unfolding does not restore the line: %q", unfolded)
+	}
+}
+
+func TestExportPrompt(t *testing.T) {
+	m := newExportModel(t)
+	path := filepath.Join(t.TempDir(), "tasks.csv")
+
+	m.handleExport()
+	m.exportPathInput.SetValue(filepath.Join(t.TempDir(), "tasks.pdf"))
+	m.handleExportPathMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !m.exportPathEditing || !strings.Contains(m.statusMsg, "unknown export format") {
+		t.Fatalf("expected an unknown format error, got %q", m.statusMsg)
+	}
+
+	m.exportPathInput.SetValue(path)
+	m.handleExportPathMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.exportPathEditing {
+		t.Fatalf("expected the prompt to close")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil || !strings.HasPrefix(string(data), "Pri,Project,Description,Urg\n") {
+		t.Fatalf("exported %q (%v)", data, err)
+	}
+	if !strings.HasPrefix(m.statusMsg, "Exported 2 tasks") {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+}
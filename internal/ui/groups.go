@@ -0,0 +1,282 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// groupState holds the group-by mode of the table. Grouped rows are
+// preceded by a header row per group; rowTask maps table rows back to
+// m.tasks because the two no longer line up.
+type groupState struct {
+	groupBy        string // "", "project", "tag", "due", "priority" or "uda:NAME"
+	groupCollapsed map[string]bool
+	groupEditing   bool
+	groupInput     textinput.Model
+
+	rowTask  []int    // table row → index in m.tasks, -1 for headers; nil when not grouped
+	taskRow  []int    // index in m.tasks → first table row, -1 when collapsed
+	rowGroup []string // table row → name of its group
+}
+
+// dueBuckets are the groups of the due grouping, in display order.
+var dueBuckets = []string{"overdue", "today", "this week", "later", "no due date"}
+
+// priorityGroups are the groups of the priority grouping, in display order.
+var priorityGroups = []string{"H", "M", "L", "no priority"}
+
+// taskGroup is a group of tasks, referenced by their index in the task list.
+type taskGroup struct {
+	name  string
+	tasks []int
+}
+
+// validateGroupBy checks a group-by value typed by the user.
+func validateGroupBy(by string) error {
+	switch by {
+	case "", "project", "tag", "due", "priority":
+		return nil
+	}
+	if name, ok := strings.CutPrefix(by, "uda:"); ok && name != "" {
+		return nil
+	}
+	return fmt.Errorf("group by project, tag, due, priority or uda:NAME")
+}
+
+// taskGroupNames returns the groups t belongs to. A task is listed under
+// each of its tags.
+func taskGroupNames(t task.Task, by string, now time.Time) []string {
+	switch by {
+	case "project":
+		if t.Project == "" {
+			return []string{"no project"}
+		}
+		return []string{t.Project}
+	case "tag":
+		if len(t.Tags) == 0 {
+			return []string{"no tags"}
+		}
+		return t.Tags
+	case "due":
+		return []string{dueBucket(t.Due, now)}
+	case "priority":
+		if t.Priority == "" {
+			return []string{"no priority"}
+		}
+		return []string{t.Priority}
+	}
+	name := strings.TrimPrefix(by, "uda:")
+	if value := t.UDA[name]; value != "" {
+		return []string{value}
+	}
+	return []string{"no " + name}
+}
+
+// dueBucket sorts a due date into overdue, today, this week (until Sunday)
+// or later.
+func dueBucket(due string, now time.Time) string {
+	ts, err := parseTaskDate(due)
+	if err != nil {
+		return "no due date"
+	}
+	ts = ts.Local()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	switch {
+	case ts.Before(now):
+		return "overdue"
+	case ts.Before(today.AddDate(0, 0, 1)):
+		return "today"
+	case ts.Before(task.WeekStart(now).AddDate(0, 0, 7)):
+		return "this week"
+	}
+	return "later"
+}
+
+// groupTasks groups tasks, keeping their order within a group. Due and
+// priority groups come in their natural order, the others alphabetically
+// with the "no …" group last.
+func groupTasks(tasks []task.Task, by string, now time.Time) []taskGroup {
+	index := make(map[string]int)
+	var groups []taskGroup
+	for i, t := range tasks {
+		for _, name := range taskGroupNames(t, by, now) {
+			g, ok := index[name]
+			if !ok {
+				g = len(groups)
+				index[name] = g
+				groups = append(groups, taskGroup{name: name})
+			}
+			groups[g].tasks = append(groups[g].tasks, i)
+		}
+	}
+	rank := func(name string) int {
+		switch by {
+		case "due":
+			return slices.Index(dueBuckets, name)
+		case "priority":
+			return slices.Index(priorityGroups, name)
+		}
+		if strings.HasPrefix(name, "no ") {
+			return 1
+		}
+		return 0
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		ri, rj := rank(groups[i].name), rank(groups[j].name)
+		if ri != rj {
+			return ri < rj
+		}
+		return by != "due" && by != "priority" && groups[i].name < groups[j].name
+	})
+	return groups
+}
+
+// groupHeader renders the header row text of a group.
+func groupHeader(g taskGroup, tasks []task.Task, collapsed bool) string {
+	var urgency float64
+	for _, i := range g.tasks {
+		urgency += tasks[i].Urgency
+	}
+	marker := "▾"
+	if collapsed {
+		marker = "▸"
+	}
+	noun := "tasks"
+	if len(g.tasks) == 1 {
+		noun = "task"
+	}
+	return fmt.Sprintf("%s %s (%d %s, urgency %.1f)", marker, g.name, len(g.tasks), noun, urgency)
+}
+
+// taskIndexAtRow returns the index in m.tasks of the task in table row r,
+// or -1 for a group header or a row out of range.
+func (m *Model) taskIndexAtRow(r int) int {
+	if m.rowTask == nil {
+		if r < 0 || r >= len(m.tasks) {
+			return -1
+		}
+		return r
+	}
+	if r < 0 || r >= len(m.rowTask) {
+		return -1
+	}
+	return m.rowTask[r]
+}
+
+// rowOfTask returns the table row of m.tasks[i], or -1 when the task is in
+// a collapsed group.
+func (m *Model) rowOfTask(i int) int {
+	if m.rowTask == nil {
+		return i
+	}
+	if i < 0 || i >= len(m.taskRow) {
+		return -1
+	}
+	return m.taskRow[i]
+}
+
+// handleGroupBy opens the group-by prompt.
+func (m *Model) handleGroupBy() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.groupEditing = true
+	m.groupInput.SetValue(m.groupBy)
+	m.groupInput.CursorEnd()
+	m.groupInput.Focus()
+	m.updateTableHeight()
+	return m, nil
+}
+
+func (m *Model) handleGroupByMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	onEnter := func(value string) error {
+		value = strings.TrimSpace(value)
+		if err := validateGroupBy(value); err != nil {
+			return err
+		}
+		m.setGroupBy(value)
+		return nil
+	}
+	onExit := func() {
+		m.groupEditing = false
+		m.updateTableHeight()
+	}
+	return m.handleTextInput(msg, &m.groupInput, onEnter, onExit)
+}
+
+// setGroupBy regroups the table, keeping the selected task selected.
+func (m *Model) setGroupBy(by string) {
+	if by != m.groupBy {
+		m.groupCollapsed = nil
+	}
+	m.groupBy = by
+	m.rebuildGroupedRows()
+}
+
+// rebuildGroupedRows re-renders the table rows after the grouping or the
+// collapsed groups changed.
+func (m *Model) rebuildGroupedRows() {
+	selected := m.taskIndexAtRow(m.tbl.Cursor())
+	m.tbl.SetRows(m.buildTaskRows(m.tasks))
+	m.tbl.SetHeaderRows(m.groupHeaderRows())
+	if row := m.rowOfTask(selected); row >= 0 {
+		m.tbl.SetCursor(row)
+	} else {
+		m.tbl.SetCursor(m.tbl.Cursor())
+	}
+	m.updateSelectionHighlight(-1, m.tbl.Cursor(), 0, m.tbl.ColumnCursor())
+}
+
+// groupHeaderRows lists the table rows that are group headers.
+func (m *Model) groupHeaderRows() []int {
+	var rows []int
+	for r, i := range m.rowTask {
+		if i < 0 {
+			rows = append(rows, r)
+		}
+	}
+	return rows
+}
+
+// handleCollapseGroup collapses the group of the selected task.
+func (m *Model) handleCollapseGroup() (tea.Model, tea.Cmd) {
+	row := m.tbl.Cursor()
+	if m.groupBy == "" || row < 0 || row >= len(m.rowGroup) || m.taskIndexAtRow(row) < 0 {
+		return m, nil
+	}
+	if m.groupCollapsed == nil {
+		m.groupCollapsed = make(map[string]bool)
+	}
+	m.groupCollapsed[m.rowGroup[row]] = true
+	m.rebuildGroupedRows()
+	return m, nil
+}
+
+// handleExpandGroup expands the nearest collapsed group above the cursor,
+// or below it when there is none above.
+func (m *Model) handleExpandGroup() (tea.Model, tea.Cmd) {
+	if m.groupBy == "" {
+		return m, nil
+	}
+	row := min(m.tbl.Cursor(), len(m.rowGroup)-1)
+	for _, dir := range []int{-1, 1} {
+		for r := row; r >= 0 && r < len(m.rowGroup); r += dir {
+			if name := m.rowGroup[r]; m.rowTask[r] < 0 && m.groupCollapsed[name] {
+				delete(m.groupCollapsed, name)
+				m.rebuildGroupedRows()
+				if first := r + 1; first < len(m.rowTask) && m.rowTask[first] >= 0 {
+					m.tbl.SetCursor(first)
+					m.updateSelectionHighlight(-1, m.tbl.Cursor(), 0, m.tbl.ColumnCursor())
+				}
+				return m, nil
+			}
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestGroupTasks(t *testing.T) {
+	now := time.Date(2026, 10, 14, 12, 0, 0, 0, time.Local) // a Wednesday
+	at := func(day, hour int) string {
+		return time.Date(2026, 10, day, hour, 0, 0, 0, time.Local).UTC().Format(task.DateFormat)
+	}
+	tasks := []task.Task{
+		{Project: "web", Due: at(20, 9), Tags: []string{"b", "a"}},
+		{Due: at(14, 18), Priority: "H"},
+		{Project: "api", Due: at(13, 9), UDA: map[string]string{"stage": "qa"}},
+		{Project: "web", Due: at(16, 9), Priority: "L"},
+		{},
+	}
+	names := func(groups []taskGroup) []string {
+		var out []string
+		for _, g := range groups {
+			out = append(out, g.name)
+		}
+		return out
+	}
+	tests := map[string][]string{
+		"project":   {"api", "web", "no project"},
+		"tag":       {"a", "b", "no tags"},
+		"due":       {"overdue", "today", "this week", "later", "no due date"},
+		"priority":  {"H", "L", "no priority"},
+		"uda:stage": {"qa", "no stage"},
+	}
+	for by, want := range tests {
+		if got := names(groupTasks(tasks, by, now)); !reflect.DeepEqual(got, want) {
+			t.Errorf("groupTasks by %s = %q, want %q", by, got, want)
+		}
+	}
+	if got := groupTasks(tasks, "project", now)[1].tasks; !reflect.DeepEqual(got, []int{0, 3}) {
+		t.Errorf("web group = %v, want the tasks in list order", got)
+	}
+	if err := validateGroupBy("uda:"); err == nil {
+		t.Error("expected uda: without a name to be rejected")
+	}
+}
+
+func TestGroupedTable(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "a", Description: "alpha", Status: "pending", Project: "web", Urgency: 2},
+		{ID: 2, UUID: "b", Description: "beta", Status: "pending", Project: "api", Urgency: 1.5},
+		{ID: 3, UUID: "c", Description: "gamma", Status: "pending", Project: "web", Urgency: 1},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.handleGroupBy()
+	m.groupInput.SetValue("project")
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.groupBy != "project" || m.groupEditing {
+		t.Fatalf("expected grouping by project, got %q", m.groupBy)
+	}
+
+	// api: beta | web: alpha, gamma
+	if got := m.groupHeaderRows(); !reflect.DeepEqual(got, []int{0, 2}) {
+		t.Fatalf("header rows = %v", got)
+	}
+	if header := m.tbl.Rows()[2][0]; header != "▾ web (2 tasks, urgency 3.0)" {
+		t.Fatalf("header = %q", header)
+	}
+	if sel, ok := m.selectedTask(); !ok || sel.ID != 1 {
+		t.Fatalf("expected the selected task to stay selected, got %+v", sel)
+	}
+	m.tbl.MoveUp(1)
+	if sel, _ := m.selectedTask(); sel.ID != 2 || m.tbl.Cursor() != 1 {
+		t.Fatalf("expected the header to be skipped, cursor %d on task %d", m.tbl.Cursor(), sel.ID)
+	}
+
+	m.searchRegex = regexp.MustCompile("gamma")
+	m.tbl.SetRows(m.buildTaskRows(m.tasks))
+	if len(m.searchMatches) != 1 || m.searchMatches[0].row != 4 {
+		t.Fatalf("search matches = %+v, want row 4", m.searchMatches)
+	}
+	m.searchRegex = nil
+
+	m.selectTaskByID(3)
+	m.handleCollapseGroup()
+	if rows := m.tbl.Rows(); len(rows) != 3 || !strings.HasPrefix(rows[2][0], "▸ web") {
+		t.Fatalf("expected the web group collapsed, rows %q", rows)
+	}
+	if sel, _ := m.selectedTask(); sel.ID != 2 {
+		t.Fatalf("expected the cursor on the remaining task, got %d", sel.ID)
+	}
+	m.tbl.GotoBottom()
+	m.handleExpandGroup()
+	if sel, _ := m.selectedTask(); len(m.tbl.Rows()) != 5 || sel.ID != 1 {
+		t.Fatalf("expected the web group expanded with its first task selected, got %d", sel.ID)
+	}
+}
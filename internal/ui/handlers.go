@@ -3,9 +3,13 @@ package ui
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"charm.land/bubbles/v2/textinput"
 	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
 )
 
 // handleTextInput provides generic text input handling for all input modes
@@ -164,12 +168,18 @@ func (m *Model) handleDueEditMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
 		ctx, cancel := m.taskOperationContext()
-		err := m.taskwarriorClient().SetDueDateContext(ctx, m.dueID, m.dueDate.Format("2006-01-02"))
+		var err error
+		if m.dueWaitUUID != "" {
+			err = m.taskwarriorClient().ModifyUUIDContext(ctx, m.dueWaitUUID, []string{"wait:" + m.dueDate.Format("2006-01-02")})
+		} else {
+			err = m.taskwarriorClient().SetDueDateContext(ctx, m.dueID, m.dueDate.Format("2006-01-02"))
+		}
 		cancel()
 		if err != nil {
 			return m, m.showErrorTimed(err)
 		}
 		m.dueEditing = false
+		m.dueWaitUUID = ""
 		if !m.reloadAndReport() {
 			return m, nil
 		}
@@ -184,6 +194,7 @@ func (m *Model) handleDueEditMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		return m, cmd
 	case "esc":
 		m.dueEditing = false
+		m.dueWaitUUID = ""
 		m.updateTableHeight()
 		return m, nil
 	}
@@ -248,6 +259,53 @@ func (m *Model) handleRecurrenceMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	return model, cmd
 }
 
+// recurPreviewCount is how many upcoming due dates the recurrence prompt
+// previews.
+const recurPreviewCount = 5
+
+// recurPreview lists the due dates the entered recurrence would produce
+// after the edited task's due date, stopping at its until date.
+func (m *Model) recurPreview() string {
+	value := strings.TrimSpace(m.recurInput.Value())
+	t := m.taskByID(m.recurID)
+	if value == "" || t == nil {
+		return ""
+	}
+	due, err := parseTaskDate(t.Due)
+	if err != nil {
+		return "(a recurring task needs a due date)"
+	}
+	var until time.Time
+	if ts, err := parseTaskDate(t.Until); err == nil {
+		until = ts.Local()
+	}
+	dates, err := task.NextOccurrences(due.Local(), value, until, recurPreviewCount)
+	if err != nil {
+		return "(unknown period)"
+	}
+	parts := make([]string, len(dates))
+	for i, d := range dates {
+		parts[i] = d.Format("Mon 2006-01-02")
+	}
+	preview := "next: " + strings.Join(parts, ", ")
+	switch {
+	case len(dates) == 0:
+		preview = "no due dates before until " + until.Format("2006-01-02")
+	case len(dates) < recurPreviewCount:
+		preview += ", then until " + until.Format("2006-01-02") + " ends the series"
+	}
+	return preview
+}
+
+// recurView renders the recurrence prompt followed by its preview.
+func (m *Model) recurView() string {
+	view := m.recurInput.View()
+	if preview := m.recurPreview(); preview != "" {
+		view += "  " + lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render(preview)
+	}
+	return view
+}
+
 // handleProjectMode handles project editing
 func (m *Model) handleProjectMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	onEnter := func(value string) error {
@@ -350,13 +408,13 @@ func (m *Model) handleFilterMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 func (m *Model) handleAddTaskMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	switch msg.String() {
 	case "enter":
-		oldIDs := make(map[int]struct{}, len(m.tasks))
-		for _, tsk := range m.tasks {
-			oldIDs[tsk.ID] = struct{}{}
-		}
-
 		ctx, cancel := m.taskOperationContext()
-		err := m.taskwarriorClient().AddLineContext(ctx, m.addInput.Value())
+		var err error
+		if args, ok := m.quickAddArgs(m.addInput.Value()); ok {
+			err = m.taskwarriorClient().AddArgsContext(ctx, args)
+		} else {
+			err = m.taskwarriorClient().AddLineContext(ctx, m.addInput.Value())
+		}
 		cancel()
 		if err != nil {
 			return m, m.showErrorTimed(err)
@@ -364,26 +422,34 @@ func (m *Model) handleAddTaskMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 
 		m.addingTask = false
 		m.addInput.Blur()
+		m.enrichRejected = nil
+		annotations := m.addAnnotations
+		m.addAnnotations = nil
+
+		// The new task is annotated even when the current filter does not
+		// list it; the table is only needed to move the cursor onto it.
+		newTask, err := m.latestTask()
+		if err != nil {
+			m.showError(err)
+		} else if len(annotations) > 0 {
+			if err := m.annotateNewTask(newTask.ID, annotations); err != nil {
+				m.showError(err)
+			}
+		}
 		if !m.reloadAndReport() {
 			return m, nil
 		}
-
-		// Find the newly added task
-		var newID int
+		newID := newTask.ID
 		row := -1
-		for i, tsk := range m.tasks {
-			if _, ok := oldIDs[tsk.ID]; !ok {
-				newID = tsk.ID
-				row = i
-				break
-			}
+		if newID != 0 {
+			row = m.taskIndexByID(newID)
 		}
 
 		m.updateTableHeight()
 		if row >= 0 {
 			prevRow := m.tbl.Cursor()
 			prevCol := m.tbl.ColumnCursor()
-			m.tbl.SetCursor(row)
+			m.tbl.SetCursor(m.rowOfTask(row))
 			m.tbl.SetColumnCursor(7) // Description column
 			m.updateSelectionHighlight(prevRow, m.tbl.Cursor(), prevCol, m.tbl.ColumnCursor())
 			if m.showUltra {
@@ -395,8 +461,18 @@ func (m *Model) handleAddTaskMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case "tab":
+		if m.acceptEnrichSuggestion() {
+			return m, nil
+		}
+	case "shift+tab":
+		if m.rejectEnrichSuggestion() {
+			return m, nil
+		}
 	case "esc":
 		m.addingTask = false
+		m.addAnnotations = nil
+		m.enrichRejected = nil
 		m.addInput.Blur()
 		m.updateTableHeight()
 		return m, nil
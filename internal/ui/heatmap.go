@@ -0,0 +1,208 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// heatmapState holds the "m" completion heatmap screen.
+type heatmapState struct {
+	heatmapActive    bool
+	heatmapTasks     []task.Task
+	heatmapFilter    string // raw filter, split with parseFilterInput
+	heatmapFiltering bool
+	heatmapInput     textinput.Model
+}
+
+const (
+	heatmapWeeks = 53 // a year of week columns
+	trendWeeks   = 26 // weeks in the created/completed trend
+)
+
+// sparkBars are the levels of a trend line, from lowest to highest.
+var sparkBars = []rune("▁▂▃▄▅▆▇█")
+
+// handleHeatmap opens the completion heatmap.
+func (m *Model) handleHeatmap() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	if err := m.loadHeatmap(m.heatmapFilter); err != nil {
+		return m, m.showErrorTimed(fmt.Errorf("loading heatmap: %w", err))
+	}
+	m.heatmapFiltering = false
+	m.heatmapActive = true
+	return m, nil
+}
+
+// loadHeatmap exports the tasks of every status matching filter.
+func (m *Model) loadHeatmap(filter string) error {
+	fields, err := parseFilterInput(filter)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	tasks, err := m.taskwarriorClient().Export(ctx, append([]string{"status.any:"}, fields...)...)
+	if err != nil {
+		return err
+	}
+	m.heatmapTasks = tasks
+	m.heatmapFilter = filter
+	return nil
+}
+
+// handleHeatmapMode handles keys on the heatmap screen.
+func (m *Model) handleHeatmapMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.heatmapFiltering {
+		onEnter := func(value string) error {
+			return m.loadHeatmap(strings.TrimSpace(value))
+		}
+		onExit := func() {
+			m.heatmapFiltering = false
+		}
+		return m.handleTextInput(msg, &m.heatmapInput, onEnter, onExit)
+	}
+	switch msg.String() {
+	case "esc", "q":
+		m.heatmapActive = false
+		m.heatmapTasks = nil
+	case "f", "/":
+		m.heatmapFiltering = true
+		m.heatmapInput.SetValue(m.heatmapFilter)
+		m.heatmapInput.CursorEnd()
+		m.heatmapInput.Focus()
+	case "c":
+		if err := m.loadHeatmap(""); err != nil {
+			return m, m.showErrorTimed(err)
+		}
+	}
+	return m, nil
+}
+
+// heatmapCell renders a day with count completions; the shade grows with
+// count relative to the busiest day.
+func (m *Model) heatmapCell(count, most int) string {
+	if count == 0 || most == 0 {
+		return lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render("·")
+	}
+	shades := m.theme.HeatmapShades
+	level := min((count*len(shades)-1)/most, len(shades)-1)
+	return lipgloss.NewStyle().Foreground(lipgloss.Color(shades[level])).Render("■")
+}
+
+// heatmapGrid renders completions per day as one column per week, ending
+// with the current one, and one row per weekday from Monday to Sunday.
+func (m *Model) heatmapGrid(days map[string]int, weeks int, now time.Time) []string {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	start := task.WeekStart(now).AddDate(0, 0, -7*(weeks-1))
+	most := 0
+	for day, count := range days {
+		if day >= start.Format(time.DateOnly) {
+			most = max(most, count)
+		}
+	}
+
+	// Label the column of the first Monday of each month, skipping labels
+	// that would overlap the previous one.
+	months := []rune(strings.Repeat(" ", 6+2*weeks))
+	next := 0
+	for week := range weeks {
+		monday := start.AddDate(0, 0, 7*week)
+		col := 6 + 2*week
+		if monday.Day() > 7 || col < next || col+3 > len(months) {
+			continue
+		}
+		copy(months[col:], []rune(monday.Format("Jan")))
+		next = col + 4
+	}
+	rows := []string{strings.TrimRight(string(months), " ")}
+	for weekday := range 7 {
+		var b strings.Builder
+		b.WriteString("  " + start.AddDate(0, 0, weekday).Format("Mon"))
+		for week := range weeks {
+			day := start.AddDate(0, 0, 7*week+weekday)
+			b.WriteString(" ")
+			if day.After(today) {
+				b.WriteString(" ")
+				continue
+			}
+			b.WriteString(m.heatmapCell(days[day.Format(time.DateOnly)], most))
+		}
+		rows = append(rows, b.String())
+	}
+	return rows
+}
+
+// sparkline renders values as a trend line scaled to most.
+func sparkline(values []int, most int) string {
+	var b strings.Builder
+	for _, v := range values {
+		if most == 0 {
+			b.WriteRune(sparkBars[0])
+			continue
+		}
+		b.WriteRune(sparkBars[v*(len(sparkBars)-1)/most])
+	}
+	return b.String()
+}
+
+// trendLines renders the weekly created and completed counts as two trend
+// lines on the same scale.
+func trendLines(trend []task.WeekTrend) []string {
+	var created, completed []int
+	most, createdSum, completedSum := 0, 0, 0
+	for _, w := range trend {
+		created = append(created, w.Created)
+		completed = append(completed, w.Completed)
+		most = max(most, w.Created, w.Completed)
+		createdSum += w.Created
+		completedSum += w.Completed
+	}
+	return []string{
+		fmt.Sprintf("  created   %s  %d", sparkline(created, most), createdSum),
+		fmt.Sprintf("  completed %s  %d", sparkline(completed, most), completedSum),
+	}
+}
+
+func (m *Model) renderHeatmapScreen() string {
+	width := m.fullScreenWidth()
+	now := time.Now()
+	weeks := min(max((width-6)/2, 1), heatmapWeeks)
+	days := task.CompletionsPerDay(m.heatmapTasks)
+	since := task.WeekStart(now).AddDate(0, 0, -7*(weeks-1)).Format(time.DateOnly)
+	total := 0
+	for day, count := range days {
+		if day >= since {
+			total += count
+		}
+	}
+	title := fmt.Sprintf("Completed since %s: %d", since, total)
+	if m.heatmapFilter != "" {
+		title += " | filter: " + m.heatmapFilter
+	}
+
+	lines := []string{""}
+	lines = append(lines, m.heatmapGrid(days, weeks, now)...)
+	legend := []string{"  less", m.heatmapCell(0, 1)}
+	for i := range m.theme.HeatmapShades {
+		legend = append(legend, m.heatmapCell(i+1, len(m.theme.HeatmapShades)))
+	}
+	lines = append(lines, strings.Join(append(legend, "more"), " "), "")
+
+	trend := task.WeeklyTrend(m.heatmapTasks, min(trendWeeks, max(width-20, 1)), now)
+	lines = append(lines, fmt.Sprintf("  Weekly trend since %s", trend[0].Start.Format(time.DateOnly)))
+	lines = append(lines, trendLines(trend)...)
+	if m.heatmapFiltering {
+		m.heatmapInput.SetWidth(width)
+		lines = append(lines, "", m.heatmapInput.View())
+	}
+
+	return m.renderFullScreen(title, lines, "f filter (project:x +tag) | c clear filter | Esc close")
+}
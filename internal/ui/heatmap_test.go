@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestHeatmapScreen(t *testing.T) {
+	yesterday := time.Now().AddDate(0, 0, -1).UTC().Format(task.DateFormat)
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{UUID: "a", Description: "done", Status: "completed", Entry: yesterday, End: yesterday},
+		{UUID: "b", Description: "open", Status: "pending", Entry: yesterday},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.windowHeight = 30
+
+	m.handleHeatmap()
+	if !m.heatmapActive {
+		t.Fatal("expected the heatmap screen to open")
+	}
+	screen := m.renderHeatmapScreen()
+	for _, want := range []string{": 1", "Mon", "Sun", "created   ", "  2", "completed ", "  1"} {
+		if !strings.Contains(screen, want) {
+			t.Fatalf("heatmap screen lacks %q:\n%s", want, screen)
+		}
+	}
+
+	m.handleHeatmapMode(tea.KeyPressMsg{Code: 'f', Text: "f"})
+	m.heatmapInput.SetValue(`project:work "+home"`)
+	m.handleHeatmapMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	last := fake.exportFilters[len(fake.exportFilters)-1]
+	if want := []string{"status.any:", "project:work", "+home"}; !reflect.DeepEqual(last, want) {
+		t.Fatalf("export filters = %q, want %q", last, want)
+	}
+	if m.heatmapFiltering || !strings.Contains(m.renderHeatmapScreen(), "filter: project:work") {
+		t.Fatal("expected the filter to be applied and shown")
+	}
+}
+
+func TestSparkline(t *testing.T) {
+	if got := sparkline([]int{0, 2, 4}, 4); got != "▁▄█" {
+		t.Fatalf("sparkline = %q", got)
+	}
+}
@@ -2,6 +2,8 @@ package ui
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
 	"regexp"
 	"strings"
 	"time"
@@ -251,3 +253,36 @@ func validateDescription(desc string) error {
 	}
 	return nil
 }
+
+// writeEditorTempFile writes content to a new temp file for $EDITOR and
+// returns its name.
+func writeEditorTempFile(pattern, content string) (string, error) {
+	f, err := os.CreateTemp("", pattern)
+	if err != nil {
+		return "", err
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return "", err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// launchEditorCmd suspends the TUI and runs $EDITOR (vi if unset) on
+// path; done builds the message sent once the editor exits.
+func launchEditorCmd(path string, done func(error) tea.Msg) tea.Cmd {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		editor = "vi"
+	}
+	c := exec.Command(editor, path)
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+	return tea.ExecProcess(c, done)
+}
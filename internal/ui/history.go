@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// handleDetailHistory loads the change journal of the detail view task and
+// switches the detail view to its History tab.
+func (m *Model) handleDetailHistory() (tea.Model, tea.Cmd) {
+	t := m.currentDetailTask()
+	if t == nil {
+		return m, nil
+	}
+	ctx, cancel := m.taskOperationContext()
+	history, err := m.taskwarriorClient().History(ctx, t.UUID)
+	cancel()
+	if err != nil {
+		return m, m.showErrorTimed(fmt.Errorf("loading history: %w", err))
+	}
+	m.detailHistory = history
+	m.detailHistoryVisible = true
+	m.detailHistoryOffset = 0
+	return m, nil
+}
+
+func (m *Model) closeDetailHistory() {
+	m.detailHistoryVisible = false
+	m.detailHistory = nil
+	m.detailHistoryOffset = 0
+}
+
+// handleDetailHistoryMode handles keys on the History tab.
+func (m *Model) handleDetailHistoryMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	maxOffset := len(m.historyLines()) - 1
+	switch msg.String() {
+	case "h", "esc":
+		m.closeDetailHistory()
+	case "q":
+		m.closeDetailHistory()
+		return m.handleQuitKey()
+	case "down", "j":
+		m.detailHistoryOffset = min(m.detailHistoryOffset+1, max(maxOffset, 0))
+	case "up", "k":
+		m.detailHistoryOffset = max(m.detailHistoryOffset-1, 0)
+	case "g", "home":
+		m.detailHistoryOffset = 0
+	case "G", "end":
+		m.detailHistoryOffset = max(maxOffset, 0)
+	}
+	return m, nil
+}
+
+// historyEntryText describes one journal entry as "field: old → new".
+func historyEntryText(e task.HistoryEntry) string {
+	switch e.Action {
+	case task.HistorySet:
+		return fmt.Sprintf("%s: set to %q", e.Field, e.New)
+	case task.HistoryChanged:
+		return fmt.Sprintf("%s: %q → %q", e.Field, e.Old, e.New)
+	case task.HistoryDeleted:
+		if e.Field == "start" {
+			return "stopped"
+		}
+		return fmt.Sprintf("%s: removed", e.Field)
+	case task.HistoryAdded:
+		return fmt.Sprintf("annotation added: %q", e.New)
+	case task.HistoryRemoved:
+		return fmt.Sprintf("annotation removed: %q", e.Old)
+	}
+	return e.Text
+}
+
+// historyLines renders the start/stop intervals followed by every change.
+func (m *Model) historyLines() []string {
+	const stamp = "2006-01-02 15:04:05"
+	var lines []string
+	if intervals := task.StartStopIntervals(m.detailHistory); len(intervals) > 0 {
+		lines = append(lines, "Start/stop intervals:")
+		var total time.Duration
+		for _, iv := range intervals {
+			if iv.Stop.IsZero() {
+				lines = append(lines, fmt.Sprintf("  %s → running", iv.Start.Format(stamp)))
+				continue
+			}
+			d := iv.Stop.Sub(iv.Start)
+			total += d
+			lines = append(lines, fmt.Sprintf("  %s → %s  (%s)", iv.Start.Format(stamp), iv.Stop.Format(stamp), d))
+		}
+		lines = append(lines, fmt.Sprintf("  total tracked: %s", total), "")
+	}
+	lines = append(lines, "Changes:")
+	if len(m.detailHistory) == 0 {
+		lines = append(lines, "  no journal entries")
+	}
+	for _, e := range m.detailHistory {
+		lines = append(lines, fmt.Sprintf("  %s  %s", e.Time.Format(stamp), historyEntryText(e)))
+	}
+	return lines
+}
+
+// renderTaskHistory renders the History tab of the detail view.
+func (m *Model) renderTaskHistory() string {
+	t := m.currentDetailTask()
+	if t == nil {
+		return "No task selected"
+	}
+	titleStyle, labelStyle, valueStyle, _ := m.detailStyles()
+
+	body := m.historyLines()
+	height := m.windowHeight - 5
+	if height < 1 {
+		height = len(body)
+	}
+	offset := min(m.detailHistoryOffset, max(len(body)-1, 0))
+	body = body[offset:min(offset+height, len(body))]
+
+	lines := []string{titleStyle.Render(fmt.Sprintf("Task %d History", t.ID)), ""}
+	for _, line := range body {
+		if strings.HasSuffix(line, ":") && !strings.HasPrefix(line, " ") {
+			lines = append(lines, labelStyle.Render(line))
+		} else {
+			lines = append(lines, valueStyle.Render(line))
+		}
+	}
+	ist := lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Italic(true)
+	lines = append(lines, "", ist.Render("Press h or ESC to return to the task fields, ↑/k and ↓/j to scroll"))
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestDetailHistoryTab(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2026, 1, 3, hour, 0, 0, 0, time.Local) }
+	fake := &fakeTaskwarrior{
+		tasks: []task.Task{{ID: 1, UUID: "u1", Description: "Report", Status: "pending"}},
+		history: []task.HistoryEntry{
+			{Time: at(9), Field: "start", Action: task.HistorySet, New: "x"},
+			{Time: at(9), Field: "priority", Action: task.HistoryChanged, Old: "M", New: "H"},
+			{Time: at(11), Field: "start", Action: task.HistoryDeleted, Duration: "PT2H"},
+			{Time: at(12), Field: "annotation", Action: task.HistoryAdded, New: "call back"},
+		},
+	}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.windowHeight = 40
+	m.setCurrentTaskDetail(&m.tasks[0])
+	m.showTaskDetail = true
+
+	m.handleTaskDetailMode(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	if !m.detailHistoryVisible {
+		t.Fatalf("expected the History tab to open")
+	}
+	view := m.renderTaskDetail()
+	for _, want := range []string{
+		"Task 1 History",
+		"2026-01-03 09:00:00 → 2026-01-03 11:00:00  (2h0m0s)",
+		`priority: "M" → "H"`,
+		"stopped",
+		`annotation added: "call back"`,
+	} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("history view missing %q:\n%s", want, view)
+		}
+	}
+
+	m.handleTaskDetailMode(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if m.detailHistoryOffset != 1 {
+		t.Fatalf("expected j to scroll, offset %d", m.detailHistoryOffset)
+	}
+	m.handleTaskDetailMode(tea.KeyPressMsg{Code: tea.KeyEscape})
+	if m.detailHistoryVisible || !m.showTaskDetail {
+		t.Fatalf("expected Esc to return to the detail fields")
+	}
+}
@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"charm.land/bubbles/v2/viewport"
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/importer"
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// importState holds the parsed tasks shown on the import preview screen.
+type importState struct {
+	importPreview  bool
+	importTasks    []task.Task
+	importDups     []bool
+	importViewport viewport.Model
+}
+
+// handleImport opens the import file prompt.
+func (m *Model) handleImport() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	m.importPathEditing = true
+	m.importPathInput.SetValue("")
+	m.importPathInput.Focus()
+	m.updateTableHeight()
+	m.statusMsg = "Import todo.txt (.txt), CSV (.csv) or Markdown checklists (.md)"
+	return m, nil
+}
+
+// expandHome replaces a leading "~/" with the user's home directory.
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+// handleImportPathMode parses the entered file and opens the preview.
+// Duplicates are detected against all pending tasks, not just the listed
+// ones.
+func (m *Model) handleImportPathMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	onEnter := func(value string) error {
+		path := strings.TrimSpace(value)
+		if path == "" {
+			return fmt.Errorf("file name cannot be empty")
+		}
+		tasks, err := importer.Load(expandHome(path), "")
+		if err != nil {
+			return err
+		}
+		if len(tasks) == 0 {
+			return fmt.Errorf("no tasks found in %s", path)
+		}
+
+		ctx, cancel := m.taskOperationContext()
+		defer cancel()
+		existing, err := m.taskwarriorClient().Export(ctx, "status:pending")
+		if err != nil {
+			return fmt.Errorf("loading pending tasks: %w", err)
+		}
+		m.openImportPreview(tasks, importer.Duplicates(tasks, existing))
+		return nil
+	}
+
+	onExit := func() {
+		m.importPathEditing = false
+	}
+
+	return m.handleTextInput(msg, &m.importPathInput, onEnter, onExit)
+}
+
+func (m *Model) openImportPreview(tasks []task.Task, dups []bool) {
+	m.importPreview = true
+	m.importTasks = tasks
+	m.importDups = dups
+
+	var b strings.Builder
+	_ = importer.WritePreview(&b, tasks, dups)
+	m.importViewport = viewport.New()
+	m.importViewport.SetContent(strings.TrimRight(b.String(), "\n"))
+}
+
+func (m *Model) closeImportPreview() {
+	m.importPreview = false
+	m.importTasks = nil
+	m.importDups = nil
+}
+
+func (m *Model) importDuplicateCount() int {
+	n := 0
+	for _, dup := range m.importDups {
+		if dup {
+			n++
+		}
+	}
+	return n
+}
+
+// handleImportPreviewMode handles keys on the import preview: y/Enter
+// imports everything except duplicates, a imports all tasks.
+func (m *Model) handleImportPreviewMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "enter":
+		return m.applyImport(importer.WithoutDuplicates(m.importTasks, m.importDups))
+	case "a":
+		return m.applyImport(m.importTasks)
+	case "n", "esc", "q":
+		m.closeImportPreview()
+		m.statusMsg = "Import cancelled"
+	case "up", "k":
+		m.importViewport.ScrollUp(1)
+	case "down", "j":
+		m.importViewport.ScrollDown(1)
+	case "pgup", "b":
+		m.importViewport.PageUp()
+	case "pgdown", "space":
+		m.importViewport.PageDown()
+	}
+	return m, nil
+}
+
+// applyImport creates tasks with a single "task import" and highlights
+// the new rows.
+func (m *Model) applyImport(tasks []task.Task) (tea.Model, tea.Cmd) {
+	skipped := len(m.importTasks) - len(tasks)
+	m.closeImportPreview()
+	if len(tasks) == 0 {
+		m.statusMsg = fmt.Sprintf("Nothing to import (%d duplicates skipped)", skipped)
+		return m, nil
+	}
+
+	ctx, cancel := m.taskOperationContext()
+	err := m.taskwarriorClient().ImportContext(ctx, tasks)
+	cancel()
+	if err != nil {
+		m.showError(fmt.Errorf("import: %w", err))
+		return m, nil
+	}
+
+	diff, ok := m.reloadWithDiff()
+	if !ok {
+		return m, nil
+	}
+	uuids := make(map[string]bool, len(diff.added))
+	for _, uuid := range diff.added {
+		uuids[uuid] = true
+	}
+	status := m.showStatusTimed(fmt.Sprintf("Imported %d tasks (%d duplicates skipped)", len(tasks), skipped))
+	return m, tea.Batch(status, m.blinkUUIDs(uuids))
+}
+
+func (m *Model) renderImportScreen() string {
+	m.importViewport.SetWidth(m.fullScreenWidth())
+	m.importViewport.SetHeight(m.fullScreenRows())
+	title := fmt.Sprintf("Import: %d tasks, %d duplicates", len(m.importTasks), m.importDuplicateCount())
+	return m.renderFullScreen(title, []string{m.importViewport.View()},
+		"y/Enter import new | a import all | n/Esc cancel | j/k scroll")
+}
@@ -0,0 +1,73 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// TestImportFlow imports a Markdown checklist through the prompt and the
+// preview, skipping the item that duplicates a pending task.
+func TestImportFlow(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.md")
+	if err := os.WriteFile(path, []byte("- [ ] Buy milk\n- [ ] Call Bob\n- [x] Done\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "existing", Description: "buy milk", Status: "pending"},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.blinkEnabled = true
+	m.windowHeight = 20
+
+	m.handleImport()
+	m.importPathInput.SetValue(path)
+	m.handleImportPathMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.importPathEditing || !m.importPreview {
+		t.Fatalf("expected the preview to open")
+	}
+	if len(m.importTasks) != 2 || m.importDuplicateCount() != 1 {
+		t.Fatalf("unexpected preview tasks %+v dups %v", m.importTasks, m.importDups)
+	}
+	if screen := m.renderImportScreen(); !strings.Contains(screen, "Call Bob") || !strings.Contains(screen, "dup") {
+		t.Fatalf("preview misses tasks:\n%s", screen)
+	}
+
+	m.handleImportPreviewMode(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	if m.importPreview {
+		t.Fatalf("expected the preview to close")
+	}
+	if len(fake.imported) != 1 || fake.imported[0].Description != "Call Bob" {
+		t.Fatalf("imported %+v", fake.imported)
+	}
+	if m.statusMsg != "Imported 1 tasks (1 duplicates skipped)" {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+	if !rowInverted(&m, "fake-2") {
+		t.Fatal("expected the imported row to blink")
+	}
+}
+
+func TestImportPathErrorKeepsPrompt(t *testing.T) {
+	m, err := NewWithTaskwarrior(nil, "firefox", &fakeTaskwarrior{})
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.handleImport()
+	m.importPathInput.SetValue(filepath.Join(t.TempDir(), "missing.csv"))
+	m.handleImportPathMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !m.importPathEditing || m.importPreview {
+		t.Fatalf("expected the prompt to stay open on error")
+	}
+	if !strings.HasPrefix(m.statusMsg, "Error:") {
+		t.Fatalf("expected an error status, got %q", m.statusMsg)
+	}
+}
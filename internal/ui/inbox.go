@@ -0,0 +1,296 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// inboxState holds the "ctrl+n" inbox wizard, which steps through the
+// unprocessed tasks one at a time. Its decisions open the inline editors of
+// the table, bound to the task on the card.
+type inboxState struct {
+	inboxFilter    []string // selects the unprocessed tasks (see SetInboxFilter)
+	inboxActive    bool
+	inboxTasks     []task.Task
+	inboxPos       int
+	inboxProjects  []string // completions of the project prompt
+	inboxEdit      string   // decision whose editor is open
+	inboxTouched   bool     // a decision was taken on the current task
+	inboxProcessed int
+	inboxCounts    map[string]int // decisions taken, by inboxDecisions key
+	inboxDone      bool           // showing the summary
+}
+
+// defaultInboxFilter selects the tasks without a project.
+var defaultInboxFilter = []string{"project:"}
+
+// inboxDecisions lists the decisions of the wizard in summary order.
+var inboxDecisions = []struct{ key, label string }{
+	{"project", "moved to a project"},
+	{"priority", "prioritized"},
+	{"due", "scheduled"},
+	{"wait", "deferred"},
+	{"tags", "tagged"},
+	{"start", "started"},
+	{"delete", "deleted"},
+	{"skip", "skipped"},
+}
+
+// SetInboxFilter sets the filter selecting the pending tasks the inbox
+// wizard processes, e.g. "+inbox". An empty filter selects the tasks
+// without a project; a filter that does not parse is returned as an error.
+func (m *Model) SetInboxFilter(filter string) error {
+	fields, err := parseFilterInput(filter)
+	if err != nil {
+		return err
+	}
+	m.inboxFilter = fields
+	return nil
+}
+
+// handleInbox opens the wizard on the unprocessed tasks, oldest first.
+func (m *Model) handleInbox() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	filter := m.inboxFilter
+	if filter == nil {
+		filter = defaultInboxFilter
+	}
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	tw := m.taskwarriorClient()
+	tasks, err := tw.Export(ctx, append(append([]string{"status:pending", "("}, filter...), ")")...)
+	if err != nil {
+		return m, m.showErrorTimed(fmt.Errorf("loading inbox: %w", err))
+	}
+	if len(tasks) == 0 {
+		return m, m.showStatusTimed("Inbox is empty")
+	}
+	slices.SortStableFunc(tasks, func(a, b task.Task) int {
+		return strings.Compare(a.Entry, b.Entry)
+	})
+	m.inboxTasks = tasks
+	m.inboxProjects = tw.LoadCompletionSources(ctx).Projects
+	m.inboxPos = 0
+	m.inboxTouched = false
+	m.inboxProcessed = 0
+	m.inboxCounts = make(map[string]int)
+	m.inboxDone = false
+	m.inboxActive = true
+	return m, nil
+}
+
+func (m *Model) closeInbox() {
+	m.inboxActive = false
+	m.inboxDone = false
+	m.inboxTasks = nil
+	m.inboxProjects = nil
+	m.inboxCounts = nil
+	m.endInboxEdit()
+	m.reloadAndReport()
+}
+
+func (m *Model) inboxTask() (task.Task, bool) {
+	if m.inboxPos < 0 || m.inboxPos >= len(m.inboxTasks) {
+		return task.Task{}, false
+	}
+	return m.inboxTasks[m.inboxPos], true
+}
+
+// handleInboxMode handles keys on the wizard. The editors (J, p, w, z, t)
+// keep the card on the task so several decisions can be taken; s, D and n
+// move on to the next task.
+func (m *Model) handleInboxMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.inboxDone {
+		switch msg.String() {
+		case "esc", "q", "enter":
+			m.closeInbox()
+		}
+		return m, nil
+	}
+	if m.inboxEdit != "" {
+		return m.handleInboxEdit(msg)
+	}
+	t, ok := m.inboxTask()
+	if !ok {
+		m.inboxDone = true
+		return m, nil
+	}
+	switch msg.String() {
+	case "esc", "q":
+		m.inboxDone = true
+	case "J":
+		m.activateProjectEdit(t.ID, t.Project)
+		m.projInput.CursorEnd()
+		m.projInput.ShowSuggestions = true
+		m.projInput.SetSuggestions(m.inboxProjects)
+		m.inboxEdit = "project"
+	case "p":
+		m.activatePriorityEdit(t.ID, t.Priority)
+		m.inboxEdit = "priority"
+	case "w":
+		m.activateDueEdit(t.ID, t.Due)
+		m.inboxEdit = "due"
+	case "z":
+		m.activateDueEdit(t.ID, t.Wait)
+		m.dueWaitUUID = t.UUID
+		m.inboxEdit = "wait"
+	case "t":
+		m.activateTagsEdit(t.ID)
+		m.inboxEdit = "tags"
+	case "s":
+		ctx, cancel := m.taskOperationContext()
+		err := m.taskwarriorClient().StartContext(ctx, t.ID)
+		cancel()
+		if err != nil {
+			return m, m.showErrorTimed(err)
+		}
+		m.inboxDecide("start")
+		m.inboxNext()
+	case "D":
+		if _, _, err := m.deleteTaskWithUndo(t); err != nil {
+			return m, m.showErrorTimed(err)
+		}
+		m.inboxDecide("delete")
+		m.inboxNext()
+	case "n", "space", "enter":
+		if !m.inboxTouched {
+			m.inboxDecide("skip")
+		}
+		m.inboxNext()
+	}
+	return m, nil
+}
+
+// handleInboxEdit passes keys to the open editor. Enter closing it means
+// the value was saved, Esc that it was cancelled.
+func (m *Model) handleInboxEdit(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	_, _, cmd := m.handleEditingModes(msg)
+	if m.projEditing || m.prioritySelecting || m.dueEditing || m.tagsEditing {
+		return m, cmd
+	}
+	if msg.String() == "enter" {
+		m.inboxDecide(m.inboxEdit)
+		m.refreshInboxTask()
+	}
+	m.endInboxEdit()
+	return m, nil
+}
+
+// endInboxEdit resets what the wizard changed on the shared editors. The
+// card hides the table, so the row blink an editor starts is dropped.
+func (m *Model) endInboxEdit() {
+	m.inboxEdit = ""
+	m.projInput.ShowSuggestions = false
+	m.projInput.SetSuggestions(nil)
+	m.blinkID, m.blinkOn, m.blinkCount = 0, false, 0
+}
+
+func (m *Model) inboxDecide(key string) {
+	m.inboxCounts[key]++
+	if key != "skip" && !m.inboxTouched {
+		m.inboxTouched = true
+		m.inboxProcessed++
+	}
+}
+
+func (m *Model) inboxNext() {
+	m.inboxPos++
+	m.inboxTouched = false
+	if m.inboxPos >= len(m.inboxTasks) {
+		m.inboxDone = true
+	}
+}
+
+// refreshInboxTask reloads the task on the card after an edit.
+func (m *Model) refreshInboxTask() {
+	t, ok := m.inboxTask()
+	if !ok {
+		return
+	}
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	tasks, err := m.taskwarriorClient().Export(ctx, t.UUID)
+	if err != nil {
+		m.showError(fmt.Errorf("reloading task: %w", err))
+		return
+	}
+	for _, updated := range tasks {
+		if updated.UUID == t.UUID {
+			m.inboxTasks[m.inboxPos] = updated
+			return
+		}
+	}
+}
+
+func (m *Model) renderInboxScreen() string {
+	if t, ok := m.inboxTask(); ok && !m.inboxDone {
+		lines := []string{"", m.renderInboxCard(t, min(m.fullScreenWidth(), 80))}
+		footer := "J project | p priority | w due | z wait | t tags | s do now | D delete | n next/skip | Esc finish"
+		if m.inboxEdit != "" {
+			lines = append(lines, "", m.ultraInputOverlay())
+			footer = "Enter save | Esc cancel"
+			if m.inboxEdit == "project" {
+				footer = "Tab complete | " + footer
+			}
+		}
+		return m.renderFullScreen(fmt.Sprintf("Inbox: task %d of %d, %d processed",
+			m.inboxPos+1, len(m.inboxTasks), m.inboxProcessed), lines, footer)
+	}
+
+	label := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.HeaderFG))
+	lines := []string{"", fmt.Sprintf("  Processed %d of %d tasks", m.inboxProcessed, len(m.inboxTasks))}
+	for _, d := range inboxDecisions {
+		if n := m.inboxCounts[d.key]; n > 0 {
+			lines = append(lines, fmt.Sprintf("    %s %3d", label.Render(fmt.Sprintf("%-20s", d.label)), n))
+		}
+	}
+	if left := len(m.inboxTasks) - m.inboxPos; left > 0 {
+		lines = append(lines, fmt.Sprintf("    %s %3d", label.Render(fmt.Sprintf("%-20s", "not reached")), left))
+	}
+	return m.renderFullScreen("Inbox summary", lines, "Enter/Esc close")
+}
+
+// renderInboxCard renders t as a bordered card of the given width.
+func (m *Model) renderInboxCard(t task.Task, width int) string {
+	label := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.HeaderFG))
+	field := func(name, value string) string {
+		if value == "" {
+			value = "-"
+		}
+		return fmt.Sprintf("%s %s", label.Render(fmt.Sprintf("%-9s", name+":")), value)
+	}
+	var tags []string
+	for _, tag := range t.Tags {
+		tags = append(tags, "+"+tag)
+	}
+	body := []string{
+		lipgloss.NewStyle().Bold(true).Render(t.Description),
+		"",
+		field("ID", fmt.Sprint(t.ID)),
+		field("Project", t.Project),
+		field("Tags", strings.Join(tags, " ")),
+		field("Priority", t.Priority),
+		field("Due", m.formatTaskDate(t.Due)),
+		field("Wait", m.formatTaskDate(t.Wait)),
+		field("Added", m.formatTaskDate(t.Entry)),
+	}
+	if len(t.Annotations) > 0 {
+		body = append(body, "", label.Render("Annotations:"))
+		for _, a := range t.Annotations {
+			body = append(body, "  "+a.Description)
+		}
+	}
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(lipgloss.Color(m.theme.HeaderFG)).
+		Padding(1, 2).
+		Width(width).
+		Render(strings.Join(body, "\n"))
+}
@@ -0,0 +1,107 @@
+package ui
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// inboxFake records the project changes and starts of the inbox wizard.
+type inboxFake struct {
+	*fakeTaskwarrior
+	projects []string
+	started  []int
+}
+
+func (f *inboxFake) SetProjectContext(_ context.Context, id int, project string) error {
+	f.projects = append(f.projects, fmt.Sprintf("%d %s", id, project))
+	return nil
+}
+
+func (f *inboxFake) StartContext(_ context.Context, id int) error {
+	f.started = append(f.started, id)
+	return nil
+}
+
+func TestInboxWizard(t *testing.T) {
+	fake := &inboxFake{fakeTaskwarrior: &fakeTaskwarrior{
+		tasks: []task.Task{
+			{ID: 1, UUID: "a", Description: "Call dentist", Status: "pending", Entry: "20261003T090000Z"},
+			{ID: 2, UUID: "b", Description: "Write blog post", Status: "pending", Entry: "20261001T090000Z"},
+			{ID: 3, UUID: "c", Description: "Old idea", Status: "pending", Entry: "20261002T090000Z"},
+		},
+		completionSources: &task.CompletionSources{Projects: []string{"dev", "home"}},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	if err := m.SetInboxFilter("+inbox"); err != nil {
+		t.Fatalf("SetInboxFilter: %v", err)
+	}
+	if err := m.SetInboxFilter(`"+inbox`); err == nil {
+		t.Fatal("expected an unterminated quote to fail")
+	}
+	m.windowHeight = 30
+	m.Update(tea.KeyPressMsg{Code: 'n', Mod: tea.ModCtrl})
+	if !m.inboxActive || len(m.inboxTasks) != 3 {
+		t.Fatalf("expected the wizard on three tasks, got %+v", m.inboxTasks)
+	}
+	want := []string{"status:pending", "(", "+inbox", ")"}
+	if filters := fake.exportFilters[len(fake.exportFilters)-1]; !reflect.DeepEqual(filters, want) {
+		t.Fatalf("inbox filter = %q, want %q", filters, want)
+	}
+	if first, _ := m.inboxTask(); first.UUID != "b" {
+		t.Fatalf("expected the oldest task first, got %q", first.Description)
+	}
+
+	// Project with completion, then a wait date; the card stays on the task.
+	m.Update(tea.KeyPressMsg{Code: 'J', Text: "J"})
+	m.Update(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !reflect.DeepEqual(fake.projects, []string{"2 home"}) {
+		t.Fatalf("project changes = %q", fake.projects)
+	}
+	m.Update(tea.KeyPressMsg{Code: 'z', Text: "z"})
+	if view := m.renderInboxScreen(); !strings.Contains(view, "Write blog post") || !strings.Contains(view, "wait: ") {
+		t.Fatalf("expected the card with the wait prompt:\n%s", view)
+	}
+	m.Update(tea.KeyPressMsg{Code: 'l', Text: "l"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	wait := time.Now().AddDate(0, 0, 1).Format("2006-01-02")
+	if !reflect.DeepEqual(fake.modifications, []string{"b wait:" + wait}) {
+		t.Fatalf("modifications = %q", fake.modifications)
+	}
+	if m.inboxPos != 0 || m.inboxEdit != "" || m.blinkID != 0 {
+		t.Fatalf("expected the card to stay on the first task, at %d", m.inboxPos)
+	}
+	m.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+
+	// Do now, then skip the last task.
+	m.Update(tea.KeyPressMsg{Code: 's', Text: "s"})
+	if !reflect.DeepEqual(fake.started, []int{3}) {
+		t.Fatalf("started = %v", fake.started)
+	}
+	m.Update(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	if !m.inboxDone {
+		t.Fatal("expected the summary after the last task")
+	}
+	view := m.renderInboxScreen()
+	for _, want := range []string{"Processed 2 of 3 tasks", "moved to a project", "deferred", "started", "skipped"} {
+		if !strings.Contains(view, want) {
+			t.Fatalf("summary lacks %q:\n%s", want, view)
+		}
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.inboxActive || m.projInput.ShowSuggestions {
+		t.Fatal("expected the wizard to close and reset the project prompt")
+	}
+}
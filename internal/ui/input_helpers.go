@@ -79,6 +79,30 @@ func (m *Model) handleEditingModes(msg tea.KeyPressMsg) (handled bool, model tea
 	case m.searching:
 		model, cmd = m.handleSearchMode(msg)
 		return true, model, cmd
+	case m.refreshIntervalEditing:
+		model, cmd = m.handleAutoRefreshIntervalMode(msg)
+		return true, model, cmd
+	case m.templatePicking:
+		model, cmd = m.handleTemplatePickMode(msg)
+		return true, model, cmd
+	case m.addFormActive:
+		model, cmd = m.handleAddFormMode(msg)
+		return true, model, cmd
+	case m.subtaskAdding:
+		model, cmd = m.handleSubtaskMode(msg)
+		return true, model, cmd
+	case m.importPathEditing:
+		model, cmd = m.handleImportPathMode(msg)
+		return true, model, cmd
+	case m.exportPathEditing:
+		model, cmd = m.handleExportPathMode(msg)
+		return true, model, cmd
+	case m.standupEditing:
+		model, cmd = m.handleStandupMode(msg)
+		return true, model, cmd
+	case m.groupEditing:
+		model, cmd = m.handleGroupByMode(msg)
+		return true, model, cmd
 	case m.shellActive:
 		model, cmd = m.handleShellMode(msg)
 		return true, model, cmd
@@ -101,10 +125,26 @@ func (m *Model) getSelectedTaskID() (int, error) {
 	return t.ID, nil
 }
 
+// selectedTask returns the task under the cursor in the active view: the
+// ultra card list in ultra mode, the table otherwise.
+func (m *Model) selectedTask() (task.Task, bool) {
+	if m.showUltra {
+		tasks := m.ultraTaskList()
+		if m.ultraCursor >= 0 && m.ultraCursor < len(tasks) {
+			return tasks[m.ultraCursor], true
+		}
+		return task.Task{}, false
+	}
+	if t := m.getTaskAtCursor(); t != nil {
+		return *t, true
+	}
+	return task.Task{}, false
+}
+
 // getTaskAtCursor returns the task at the current cursor position
 func (m *Model) getTaskAtCursor() *task.Task {
-	cursor := m.tbl.Cursor()
-	if cursor < 0 || cursor >= len(m.tasks) {
+	cursor := m.taskIndexAtRow(m.tbl.Cursor())
+	if cursor < 0 {
 		return nil
 	}
 	return &m.tasks[cursor]
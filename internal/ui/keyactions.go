@@ -241,21 +241,9 @@ func (m *Model) handleOpenURLDone(msg openURLDoneMsg) (tea.Model, tea.Cmd) {
 }
 
 // openFileInEditorCmd opens path in $EDITOR (falling back to vi) in the
-// foreground. It mirrors launchDescriptionEditorCmd: tea.ExecProcess suspends
-// the TUI, runs the editor attached to the real terminal, and restores the TUI
-// once the editor exits.
+// foreground and reports back with an openFileDoneMsg.
 func openFileInEditorCmd(path string, taskID int) tea.Cmd {
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vi"
-	}
-
-	c := exec.Command(editor, path)
-	c.Stdin = os.Stdin
-	c.Stdout = os.Stdout
-	c.Stderr = os.Stderr
-
-	return tea.ExecProcess(c, func(err error) tea.Msg {
+	return launchEditorCmd(path, func(err error) tea.Msg {
 		return openFileDoneMsg{err: err, taskID: taskID}
 	})
 }
@@ -276,7 +264,7 @@ func (m *Model) handleUndo() (tea.Model, tea.Cmd) {
 	action := m.undoStack[len(m.undoStack)-1]
 	ctx, cancel := m.taskOperationContext()
 	for _, restore := range action.restores {
-		if err := m.taskwarriorClient().SetStatusUUIDContext(ctx, restore.uuid, restore.status); err != nil {
+		if err := m.applyUndoRestore(ctx, restore); err != nil {
 			cancel()
 			m.showError(err)
 			return m, nil
@@ -315,7 +303,9 @@ func (m *Model) handleUndo() (tea.Model, tea.Cmd) {
 			if m.filters != nil {
 				filters = append(filters, m.filters...)
 			}
-			filters = append(filters, "status:"+restore.status)
+			if restore.args == nil {
+				filters = append(filters, "status:"+restore.status)
+			}
 
 			ctx, cancel := m.taskOperationContext()
 			tasks, err := m.taskwarriorClient().Export(ctx, filters...)
@@ -460,14 +450,33 @@ func (m *Model) rollbackUndoRestores(restores []undoRestore) error {
 
 	var errs []error
 	for i := len(restores) - 1; i >= 0; i-- {
-		if err := m.taskwarriorClient().SetStatusUUIDContext(ctx, restores[i].uuid, restores[i].status); err != nil {
-			errs = append(errs, fmt.Errorf("restoring task %s to %s: %w", restores[i].uuid, restores[i].status, err))
+		if err := m.applyUndoRestore(ctx, restores[i]); err != nil {
+			target := "to " + restores[i].status
+			if restores[i].args != nil {
+				target = "with " + strings.Join(restores[i].args, " ")
+			}
+			errs = append(errs, fmt.Errorf("restoring task %s %s: %w", restores[i].uuid, target, err))
 		}
 	}
 	return errors.Join(errs...)
 }
 
+// applyUndoRestore puts a task back into its state before an undoable
+// action: a status change, or a modification for refactorings.
+func (m *Model) applyUndoRestore(ctx context.Context, restore undoRestore) error {
+	if restore.args != nil {
+		return m.taskwarriorClient().ModifyUUIDContext(ctx, restore.uuid, restore.args)
+	}
+	return m.taskwarriorClient().SetStatusUUIDContext(ctx, restore.uuid, restore.status)
+}
+
 func undoStatus(action undoAction) string {
+	switch action.label {
+	case "refactor":
+		return fmt.Sprintf("Refactoring undone on %d task(s)", len(action.restores))
+	case "rewrite":
+		return fmt.Sprintf("Rewrite undone on %d task(s)", len(action.restores))
+	}
 	if action.label == "delete" && len(action.restores) > 1 {
 		return "Tasks restored"
 	}
@@ -785,12 +794,9 @@ func (m *Model) handleToggleAutoRefresh() (tea.Model, tea.Cmd) {
 		m.statusMsg = "Auto-refresh off"
 		return m, nil
 	}
-	if m.autoRefreshInterval <= 0 {
-		m.autoRefreshInterval = autoRefreshDefaultInterval
-	}
-	m.autoRefreshGen++
+	m.restartAutoRefresh()
 	m.statusMsg = fmt.Sprintf("Auto-refresh on (every %s)", m.autoRefreshInterval)
-	return m, autoRefreshCmd(m.autoRefreshInterval, m.autoRefreshGen)
+	return m, autoRefreshCmd(m.currentAutoRefreshInterval(), m.autoRefreshGen)
 }
 
 func toggleAgentFilter(filters []string) []string {
@@ -822,8 +828,15 @@ func toggleAgentFilter(filters []string) []string {
 }
 
 func (m *Model) handleRefresh() (tea.Model, tea.Cmd) {
-	m.reloadAndReport()
-	return m, nil
+	diff, ok := m.reloadWithDiff()
+	if !ok {
+		return m, nil
+	}
+	if diff.empty() {
+		m.statusMsg = "No changes"
+		return m, nil
+	}
+	return m, tea.Batch(m.showStatusTimed(diff.summary()), m.blinkUUIDs(diff.touched()))
 }
 
 func (m *Model) handleSearch() (tea.Model, tea.Cmd) {
@@ -995,8 +1008,8 @@ func (m *Model) handleJumpToRandomTask() (tea.Model, tea.Cmd) {
 	// Update cursor position
 	prevRow := m.tbl.Cursor()
 	prevCol := m.tbl.ColumnCursor()
-	m.tbl.SetCursor(randomIndex)
-	m.updateSelectionHighlight(prevRow, randomIndex, prevCol, m.tbl.ColumnCursor())
+	m.tbl.SetCursor(m.rowOfTask(randomIndex))
+	m.updateSelectionHighlight(prevRow, m.tbl.Cursor(), prevCol, m.tbl.ColumnCursor())
 
 	// Blink the task to indicate jump
 	if randomIndex < len(m.tasks) {
@@ -1029,8 +1042,8 @@ func (m *Model) handleJumpToRandomTaskNoDue() (tea.Model, tea.Cmd) {
 	// Update cursor position
 	prevRow := m.tbl.Cursor()
 	prevCol := m.tbl.ColumnCursor()
-	m.tbl.SetCursor(randomIndex)
-	m.updateSelectionHighlight(prevRow, randomIndex, prevCol, m.tbl.ColumnCursor())
+	m.tbl.SetCursor(m.rowOfTask(randomIndex))
+	m.updateSelectionHighlight(prevRow, m.tbl.Cursor(), prevCol, m.tbl.ColumnCursor())
 
 	// Blink the task to indicate jump
 	if randomIndex < len(m.tasks) {
@@ -61,6 +61,22 @@ var sharedKeyBindings = []keyBinding{
 	{keys: []string{":"}, modes: keyBindingAll, desc: "run task command prompt", action: modelKeyAction((*Model).handleShellPrompt)},
 	{keys: []string{";"}, modes: keyBindingAll, desc: "run task command prompt for selected task", action: modelKeyAction((*Model).handleShellPromptForSelectedTask)},
 	{keys: []string{"+"}, modes: keyBindingAll, desc: "add new task", action: sharedKeyAction(func(h sharedKeyHandlers) func() (tea.Model, tea.Cmd) { return h.addTask })},
+	{keys: []string{"M"}, modes: keyBindingAll, desc: "add new task from template", action: modelKeyAction((*Model).handleTemplatePicker)},
+	{keys: []string{"F"}, modes: keyBindingAll, desc: "add new task with a form", action: modelKeyAction((*Model).handleAddTaskForm)},
+	{keys: []string{"S"}, modes: keyBindingAll, desc: "add subtask of selected task", action: modelKeyAction((*Model).handleAddSubtask)},
+	{keys: []string{"V"}, modes: keyBindingAll, desc: "bulk edit listed tasks in $EDITOR", action: modelKeyAction((*Model).handleBulkEdit)},
+	{keys: []string{"L"}, modes: keyBindingAll, desc: "batch add tasks from a list", action: modelKeyAction((*Model).handleBatchAdd)},
+	{keys: []string{"I"}, modes: keyBindingAll, desc: "import todo.txt/CSV/Markdown file", action: modelKeyAction((*Model).handleImport)},
+	{keys: []string{"X"}, modes: keyBindingAll, desc: "export listed tasks (md/csv/json/html/ics)", action: modelKeyAction((*Model).handleExport)},
+	{keys: []string{"Y"}, modes: keyBindingAll, desc: "standup report since a date", action: modelKeyAction((*Model).handleStandup)},
+	{keys: []string{"="}, modes: keyBindingAll, desc: "explain urgency of selected task", action: modelKeyAction((*Model).handleUrgencyBreakdown)},
+	{keys: []string{"~"}, modes: keyBindingAll, desc: "tune urgency coefficients (preview)", action: modelKeyAction((*Model).handleUrgencyTune)},
+	{keys: []string{"P"}, modes: keyBindingAll, desc: "manage recurring series", action: modelKeyAction((*Model).handleSeriesManager)},
+	{keys: []string{"m"}, modes: keyBindingAll, desc: "completion heatmap and weekly trend", action: modelKeyAction((*Model).handleHeatmap)},
+	{keys: []string{"K"}, modes: keyBindingAll, desc: "kanban board", action: modelKeyAction((*Model).handleBoard)},
+	{keys: []string{"ctrl+t"}, modes: keyBindingAll, desc: "rename, merge or remove projects and tags", action: modelKeyAction((*Model).handleRefactor)},
+	{keys: []string{"ctrl+w"}, modes: keyBindingAll, desc: "preview and apply rewrite rules", action: modelKeyAction((*Model).handleRules)},
+	{keys: []string{"ctrl+n"}, modes: keyBindingAll, desc: "process the inbox one task at a time", action: modelKeyAction((*Model).handleInbox)},
 	{keys: []string{"t"}, modes: keyBindingAll, desc: "edit tags", action: sharedKeyAction(func(h sharedKeyHandlers) func() (tea.Model, tea.Cmd) { return h.editTags })},
 	{keys: []string{"J"}, modes: keyBindingAll, desc: "edit project", action: sharedKeyAction(func(h sharedKeyHandlers) func() (tea.Model, tea.Cmd) { return h.editProject })},
 	{keys: []string{"c"}, modes: keyBindingAll, desc: "random theme", action: modelKeyAction((*Model).handleRandomTheme)},
@@ -69,6 +85,7 @@ var sharedKeyBindings = []keyBinding{
 	{keys: []string{"B"}, modes: keyBindingAll, desc: "toggle blinking", action: modelKeyAction((*Model).handleToggleBlink)},
 	{keys: []string{"v"}, modes: keyBindingAll, desc: "toggle compact view", action: modelKeyAction((*Model).handleToggleCompactView)},
 	{keys: []string{"Z"}, modes: keyBindingAll, desc: "toggle auto-refresh", action: modelKeyAction((*Model).handleToggleAutoRefresh)},
+	{keys: []string{"z"}, modes: keyBindingAll, desc: "set auto-refresh interval", action: modelKeyAction((*Model).handleAutoRefreshIntervalPrompt)},
 	{keys: []string{"space"}, modes: keyBindingAll, desc: "refresh tasks", action: modelKeyAction((*Model).handleRefresh)},
 }
 
@@ -131,10 +148,20 @@ func (m *Model) handleNormalMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 	case "u":
 		m.ultraClearFocusedID()
 		m.showUltra = true
-		m.ultraCursor = m.tbl.Cursor()
+		m.ultraCursor = max(m.taskIndexAtRow(m.tbl.Cursor()), 0)
 		m.ultraOffset = 0
 		m.ultraEnsureVisible()
 		return m, nil
+	case "ctrl+g":
+		return m.handleGroupBy()
+	case ">":
+		return m.handleCollapseGroup()
+	case "<":
+		return m.handleExpandGroup()
+	case "[":
+		return m.handleToggleSidebar()
+	case "]":
+		return m.handleFocusSidebar()
 	case "1":
 		return m.handleJumpToRandomTask()
 	case "2":
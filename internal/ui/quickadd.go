@@ -0,0 +1,47 @@
+package ui
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// SetQuickAdd turns the natural-language parsing of the add prompt on or
+// off (see task.ParseQuickAdd).
+func (m *Model) SetQuickAdd(on bool) {
+	m.quickAdd = on
+}
+
+// quickAddArgs returns the `task add` arguments of line when quick add is on
+// and recognized a phrase in it; otherwise the line is added as typed.
+func (m *Model) quickAddArgs(line string) ([]string, bool) {
+	if !m.quickAdd {
+		return nil, false
+	}
+	args, changed, err := task.ParseQuickAdd(line, time.Now())
+	if err != nil || !changed {
+		return nil, false
+	}
+	return args, true
+}
+
+// quickAddPreview renders the `task add` command the add line results in.
+func (m *Model) quickAddPreview() string {
+	line := m.addInput.Value()
+	preview := "task add " + line
+	if args, ok := m.quickAddArgs(line); ok {
+		quoted := make([]string, len(args))
+		for i, arg := range args {
+			quoted[i] = arg
+			if strings.ContainsAny(arg, " \t\"'") {
+				quoted[i] = strconv.Quote(arg)
+			}
+		}
+		preview = "task add " + strings.Join(quoted, " ")
+	}
+	return lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("→ " + preview)
+}
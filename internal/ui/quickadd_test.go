@@ -0,0 +1,38 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+func TestQuickAdd(t *testing.T) {
+	fake := &fakeTaskwarrior{}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.SetQuickAdd(true)
+
+	m.handleAddTask()
+	m.addInput.SetValue("Submit weekly report 2026-12-04 3pm !high #work @office")
+	if view := m.addView(); !strings.Contains(view, `task add Submit weekly report due:2026-12-04T15:00:00 priority:H +work project:office`) {
+		t.Fatalf("add prompt lacks the preview: %q", view)
+	}
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	want := []string{"Submit", "weekly", "report", "due:2026-12-04T15:00:00", "priority:H", "+work", "project:office"}
+	if len(fake.addArgs) != 1 || !reflect.DeepEqual(fake.addArgs[0], want) {
+		t.Fatalf("added %q, want %q", fake.addArgs, want)
+	}
+
+	m.blinkID = 0 // the new task blinks, which swallows the next key
+	// Raw Taskwarrior syntax is added as typed.
+	m.handleAddTask()
+	m.addInput.SetValue("Fix login +bug due:eow")
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if len(fake.addLines) != 1 || fake.addLines[0] != "Fix login +bug due:eow" {
+		t.Fatalf("added lines %q", fake.addLines)
+	}
+}
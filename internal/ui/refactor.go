@@ -0,0 +1,373 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// refactorState holds the "ctrl+t" project and tag manager. A rename,
+// merge or removal is previewed before it runs and is undone as one step.
+type refactorState struct {
+	refactorActive    bool
+	refactorItems     []refactorItem
+	refactorCursor    int
+	refactorCompleted bool // include completed tasks
+	refactorTasks     []task.Task
+	refactorPrompt    string // "rename" or "merge" while asking for the target
+	refactorInput     textinput.Model
+	refactorPreview   []refactorChange // planned changes while previewing
+	refactorTitle     string           // what the previewed changes do
+}
+
+// refactorItem is a project or tag of the manager with the number of tasks
+// using it. A project counts its subprojects' tasks too, as a rename or
+// removal reaches them.
+type refactorItem struct {
+	kind  string // "project" or "tag"
+	name  string
+	count int
+}
+
+// refactorChange is the modification of one task and the one undoing it.
+type refactorChange struct {
+	task task.Task
+	args []string
+	undo []string
+}
+
+// handleRefactor opens the project and tag manager.
+func (m *Model) handleRefactor() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	m.refactorCursor = 0
+	m.refactorCompleted = false
+	if err := m.loadRefactorItems(); err != nil {
+		return m, m.showErrorTimed(fmt.Errorf("loading projects and tags: %w", err))
+	}
+	m.refactorActive = true
+	return m, nil
+}
+
+func (m *Model) closeRefactor() {
+	m.refactorActive = false
+	m.refactorPrompt = ""
+	m.refactorInput.Blur()
+	m.refactorPreview = nil
+	m.refactorItems = nil
+	m.refactorTasks = nil
+}
+
+// refactorFilter selects the tasks a refactoring changes: pending, waiting
+// and recurring templates, so future instances follow, and optionally the
+// completed ones. Each task is modified on its own, without Taskwarrior's
+// recurrence prompt (see task.ModifyUUIDContext).
+func refactorFilter(completed bool) string {
+	if completed {
+		return "( status:pending or status:waiting or status:recurring or status:completed )"
+	}
+	return "( status:pending or status:waiting or status:recurring )"
+}
+
+// loadRefactorItems lists the projects and tags Taskwarrior knows with
+// their usage counts.
+func (m *Model) loadRefactorItems() error {
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	tw := m.taskwarriorClient()
+	tasks, err := tw.Export(ctx, refactorFilter(m.refactorCompleted))
+	if err != nil {
+		return err
+	}
+	m.refactorTasks = tasks
+	m.refactorItems = refactorItems(tw.LoadCompletionSources(ctx), tasks)
+	m.refactorCursor = min(m.refactorCursor, max(len(m.refactorItems)-1, 0))
+	return nil
+}
+
+// refactorItems lists the projects, then the tags, of sources and of tasks
+// alphabetically with their counts.
+func refactorItems(sources task.CompletionSources, tasks []task.Task) []refactorItem {
+	projects := slices.Clone(sources.Projects)
+	tags := slices.Clone(sources.Tags)
+	for _, t := range tasks {
+		if t.Project != "" {
+			projects = append(projects, t.Project)
+		}
+		tags = append(tags, t.Tags...)
+	}
+	var items []refactorItem
+	for _, kind := range []string{"project", "tag"} {
+		names := projects
+		if kind == "tag" {
+			names = tags
+		}
+		sort.Strings(names)
+		for _, name := range slices.Compact(names) {
+			item := refactorItem{kind: kind, name: name}
+			for _, t := range tasks {
+				if refactorMatches(t, kind, name) {
+					item.count++
+				}
+			}
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// refactorMatches reports whether a refactoring of the project or tag name
+// changes t.
+func refactorMatches(t task.Task, kind, name string) bool {
+	if kind == "tag" {
+		return slices.Contains(t.Tags, name)
+	}
+	return t.Project == name || strings.HasPrefix(t.Project, name+".")
+}
+
+// planRefactor plans renaming (or merging) the project or tag from into to,
+// or removing it for an empty to. Subprojects move along with a project.
+func planRefactor(tasks []task.Task, kind, from, to string) []refactorChange {
+	var changes []refactorChange
+	for _, t := range tasks {
+		if !refactorMatches(t, kind, from) {
+			continue
+		}
+		c := refactorChange{task: t}
+		switch {
+		case kind == "project" && to == "":
+			c.args = []string{"project:"}
+			c.undo = []string{"project:" + t.Project}
+		case kind == "project":
+			c.args = []string{"project:" + to + strings.TrimPrefix(t.Project, from)}
+			c.undo = []string{"project:" + t.Project}
+		default:
+			c.args = []string{"-" + from}
+			c.undo = []string{"+" + from}
+			if to != "" && !slices.Contains(t.Tags, to) {
+				c.args = append(c.args, "+"+to)
+				c.undo = append(c.undo, "-"+to)
+			}
+		}
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+func (m *Model) selectedRefactorItem() (refactorItem, bool) {
+	if m.refactorCursor < 0 || m.refactorCursor >= len(m.refactorItems) {
+		return refactorItem{}, false
+	}
+	return m.refactorItems[m.refactorCursor], true
+}
+
+// handleRefactorMode handles keys on the manager: r renames, m merges into
+// another project or tag, d removes, c includes completed tasks.
+func (m *Model) handleRefactorMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.refactorPrompt != "" {
+		return m.handleRefactorPrompt(msg)
+	}
+	if m.refactorPreview != nil {
+		switch msg.String() {
+		case "y", "enter":
+			return m, m.applyRefactor()
+		case "n", "esc", "q":
+			m.refactorPreview = nil
+		}
+		return m, nil
+	}
+	item, ok := m.selectedRefactorItem()
+	switch msg.String() {
+	case "esc", "q":
+		m.closeRefactor()
+		m.reloadAndReport()
+	case "down", "j":
+		m.refactorCursor = min(m.refactorCursor+1, max(len(m.refactorItems)-1, 0))
+	case "up", "k":
+		m.refactorCursor = max(m.refactorCursor-1, 0)
+	case "home", "g":
+		m.refactorCursor = 0
+	case "end", "G":
+		m.refactorCursor = max(len(m.refactorItems)-1, 0)
+	case "c":
+		m.refactorCompleted = !m.refactorCompleted
+		if err := m.loadRefactorItems(); err != nil {
+			return m, m.showErrorTimed(fmt.Errorf("loading projects and tags: %w", err))
+		}
+	case "r", "m":
+		if ok {
+			m.refactorPrompt = "rename"
+			m.refactorInput.Prompt = fmt.Sprintf("rename %s %s to: ", item.kind, item.name)
+			m.refactorInput.SetValue(item.name)
+			if msg.String() == "m" {
+				m.refactorPrompt = "merge"
+				m.refactorInput.Prompt = fmt.Sprintf("merge %s %s into: ", item.kind, item.name)
+				m.refactorInput.SetValue("")
+			}
+			m.refactorInput.CursorEnd()
+			m.refactorInput.Focus()
+		}
+	case "d":
+		if ok {
+			m.previewRefactor(item, "", fmt.Sprintf("Remove %s %s", item.kind, item.name))
+		}
+	}
+	return m, nil
+}
+
+func (m *Model) handleRefactorPrompt(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	onEnter := func(value string) error {
+		item, ok := m.selectedRefactorItem()
+		if !ok {
+			return nil
+		}
+		to, err := m.validateRefactorTarget(item, strings.TrimSpace(value))
+		if err != nil {
+			return err
+		}
+		title := fmt.Sprintf("Rename %s %s to %s", item.kind, item.name, to)
+		if m.refactorPrompt == "merge" {
+			title = fmt.Sprintf("Merge %s %s into %s", item.kind, item.name, to)
+		}
+		m.previewRefactor(item, to, title)
+		return nil
+	}
+	onExit := func() {
+		m.refactorPrompt = ""
+	}
+	return m.handleTextInput(msg, &m.refactorInput, onEnter, onExit)
+}
+
+// validateRefactorTarget checks the new name of a rename or merge. A rename
+// must pick a new name, a merge an existing one of the same kind.
+func (m *Model) validateRefactorTarget(item refactorItem, to string) (string, error) {
+	if item.kind == "tag" {
+		to = strings.TrimPrefix(to, "+")
+		if strings.ContainsFunc(to, func(r rune) bool { return r == ' ' || r == '\t' }) {
+			return "", fmt.Errorf("tags cannot contain spaces")
+		}
+	}
+	if to == "" || to == item.name {
+		return "", fmt.Errorf("enter a different %s name", item.kind)
+	}
+	exists := slices.ContainsFunc(m.refactorItems, func(other refactorItem) bool {
+		return other.kind == item.kind && other.name == to
+	})
+	switch {
+	case m.refactorPrompt == "merge" && !exists:
+		return "", fmt.Errorf("%s %s does not exist; use r to rename", item.kind, to)
+	case m.refactorPrompt == "rename" && exists:
+		return "", fmt.Errorf("%s %s exists; use m to merge", item.kind, to)
+	case item.kind == "project" && strings.HasPrefix(to, item.name+"."):
+		return "", fmt.Errorf("cannot move project %s into its own subproject", item.name)
+	}
+	return to, nil
+}
+
+// previewRefactor plans the changes and shows them for confirmation.
+func (m *Model) previewRefactor(item refactorItem, to, title string) {
+	changes := planRefactor(m.refactorTasks, item.kind, item.name, to)
+	if len(changes) == 0 {
+		m.statusMsg = fmt.Sprintf("No tasks use %s %s", item.kind, item.name)
+		return
+	}
+	m.refactorPreview = changes
+	m.refactorTitle = title
+}
+
+// applyRefactor runs the previewed changes. A failure rolls the finished
+// ones back, so the refactoring is applied as a whole or not at all, and a
+// success is pushed as a single undo step.
+func (m *Model) applyRefactor() tea.Cmd {
+	changes, title := m.refactorPreview, m.refactorTitle
+	m.refactorPreview = nil
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	var restores []undoRestore
+	for _, c := range changes {
+		if err := m.taskwarriorClient().ModifyUUIDContext(ctx, c.task.UUID, c.args); err != nil {
+			err = fmt.Errorf("modifying task %s: %w", c.task.UUID, err)
+			if rollbackErr := m.rollbackUndoRestores(restores); rollbackErr != nil {
+				err = fmt.Errorf("%w; rollback failed: %w", err, rollbackErr)
+			}
+			return m.showErrorTimed(err)
+		}
+		restores = append(restores, undoRestore{uuid: c.task.UUID, args: c.undo})
+	}
+	m.pushUndoAction("refactor", restores)
+	if err := m.loadRefactorItems(); err != nil {
+		return m.showErrorTimed(fmt.Errorf("loading projects and tags: %w", err))
+	}
+	return m.showStatusTimed(fmt.Sprintf("%s: %d task(s) changed (U in the task list undoes)", title, len(changes)))
+}
+
+func (m *Model) renderRefactorScreen() string {
+	selected := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.theme.SelectedFG)).
+		Background(lipgloss.Color(m.theme.SelectedBG))
+	header := lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color(m.theme.HeaderFG))
+	rows := m.fullScreenRows()
+
+	scope := "pending tasks"
+	if m.refactorCompleted {
+		scope = "pending and completed tasks"
+	}
+	if m.refactorPreview != nil {
+		var lines []string
+		for i, c := range m.refactorPreview {
+			if len(lines) == rows-1 && i < len(m.refactorPreview)-1 {
+				lines = append(lines, fmt.Sprintf("  … %d more", len(m.refactorPreview)-i))
+				break
+			}
+			id := "-"
+			if c.task.ID != 0 {
+				id = fmt.Sprint(c.task.ID)
+			}
+			lines = append(lines, fmt.Sprintf("  %4s  %-24s %s", id, strings.Join(c.args, " "), c.task.Description))
+		}
+		return m.renderFullScreen(fmt.Sprintf("%s: %d task(s)", m.refactorTitle, len(m.refactorPreview)), lines,
+			"y/Enter apply | n/Esc cancel")
+	}
+
+	var lines []string
+	if m.refactorPrompt != "" {
+		rows--
+	}
+	offset := max(m.refactorCursor-rows+1, 0)
+	kind := ""
+	for i := offset; i < len(m.refactorItems) && len(lines) < rows; i++ {
+		item := m.refactorItems[i]
+		if item.kind != kind {
+			kind = item.kind
+			lines = append(lines, header.Render(fmt.Sprintf("  %ss", kind)))
+		}
+		name := item.name
+		if kind == "tag" {
+			name = "+" + name
+		}
+		row := fmt.Sprintf("    %-30s %5d", name, item.count)
+		if i == m.refactorCursor {
+			row = selected.Render(row)
+		}
+		lines = append(lines, row)
+	}
+	if len(m.refactorItems) == 0 {
+		lines = append(lines, "  no projects or tags")
+	}
+	if m.refactorPrompt != "" {
+		for len(lines) < rows {
+			lines = append(lines, "")
+		}
+		m.refactorInput.SetWidth(max(m.fullScreenWidth()-len(m.refactorInput.Prompt)-1, 1))
+		lines = append(lines, m.refactorInput.View())
+	}
+	return m.renderFullScreen(fmt.Sprintf("Projects and tags of %s", scope), lines,
+		"j/k select | r rename | m merge | d remove | c include completed | Esc close")
+}
@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestPlanRefactor(t *testing.T) {
+	tasks := []task.Task{
+		{UUID: "a", Project: "work", Tags: []string{"bug", "fix"}},
+		{UUID: "b", Project: "work.api", Tags: []string{"bug"}},
+		{UUID: "c", Project: "workshop"},
+	}
+	plan := func(kind, from, to string) map[string][2]string {
+		out := make(map[string][2]string)
+		for _, c := range planRefactor(tasks, kind, from, to) {
+			out[c.task.UUID] = [2]string{strings.Join(c.args, " "), strings.Join(c.undo, " ")}
+		}
+		return out
+	}
+	tests := []struct {
+		kind, from, to string
+		want           map[string][2]string
+	}{
+		{"project", "work", "job", map[string][2]string{
+			"a": {"project:job", "project:work"},
+			"b": {"project:job.api", "project:work.api"},
+		}},
+		{"project", "work", "", map[string][2]string{
+			"a": {"project:", "project:work"},
+			"b": {"project:", "project:work.api"},
+		}},
+		{"tag", "bug", "fix", map[string][2]string{
+			"a": {"-bug", "+bug"},
+			"b": {"-bug +fix", "+bug -fix"},
+		}},
+	}
+	for _, tt := range tests {
+		if got := plan(tt.kind, tt.from, tt.to); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("planRefactor(%s %q → %q) = %q, want %q", tt.kind, tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	items := refactorItems(task.CompletionSources{Projects: []string{"work", "old"}, Tags: []string{"next"}}, tasks)
+	want := []refactorItem{
+		{"project", "old", 0}, {"project", "work", 2}, {"project", "work.api", 1}, {"project", "workshop", 1},
+		{"tag", "bug", 2}, {"tag", "fix", 1}, {"tag", "next", 0},
+	}
+	if !reflect.DeepEqual(items, want) {
+		t.Errorf("refactorItems = %+v, want %+v", items, want)
+	}
+}
+
+func TestRefactorRenameAndUndo(t *testing.T) {
+	fake := &fakeTaskwarrior{
+		tasks: []task.Task{
+			{ID: 1, UUID: "a", Description: "alpha", Status: "pending", Tags: []string{"bug"}},
+			{ID: 2, UUID: "b", Description: "beta", Status: "pending", Tags: []string{"bug", "defect"}},
+		},
+		completionSources: &task.CompletionSources{},
+	}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.windowHeight = 20
+	m.Update(tea.KeyPressMsg{Code: 't', Mod: tea.ModCtrl})
+	if !m.refactorActive || len(m.refactorItems) != 2 {
+		t.Fatalf("expected the manager with two tags, got %+v", m.refactorItems)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	m.refactorInput.SetValue("bug")
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.refactorPrompt == "" {
+		t.Fatal("expected merging a tag into itself to be rejected")
+	}
+	m.refactorInput.SetValue("+defect")
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if len(m.refactorPreview) != 2 {
+		t.Fatalf("expected a preview of two tasks, got %d", len(m.refactorPreview))
+	}
+	if screen := m.renderRefactorScreen(); !strings.Contains(screen, "Merge tag bug into defect: 2 task(s)") {
+		t.Fatalf("preview lacks its title:\n%s", screen)
+	}
+	if len(fake.modifications) != 0 {
+		t.Fatal("expected nothing to change before confirming")
+	}
+	m.Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	if want := []string{"a -bug +defect", "b -bug"}; !reflect.DeepEqual(fake.modifications, want) {
+		t.Fatalf("modifications = %q, want %q", fake.modifications, want)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEsc})
+	if m.refactorActive {
+		t.Fatal("expected esc to close the manager")
+	}
+	fake.modifications = nil
+	m.Update(tea.KeyPressMsg{Code: 'U', Text: "U"})
+	if want := []string{"a +bug -defect", "b +bug"}; !reflect.DeepEqual(fake.modifications, want) {
+		t.Fatalf("undo modifications = %q, want %q", fake.modifications, want)
+	}
+	if len(m.undoStack) != 0 {
+		t.Fatal("expected the refactoring to be a single undo step")
+	}
+}
@@ -0,0 +1,217 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/config"
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// rulesState holds the "ctrl+w" rewrite rule preview. The rules run on the
+// selected task, the tasks with a search match or every listed task, and
+// nothing changes before the preview is confirmed.
+type rulesState struct {
+	rulesActive bool
+	rules       []namedRule
+	rulesScope  string // rulesScopeTask, rulesScopeSearch or rulesScopeView
+	rulesPlan   []rewriteChange
+}
+
+const (
+	rulesScopeTask   = "selected task"
+	rulesScopeSearch = "search matches"
+	rulesScopeView   = "all listed tasks"
+)
+
+type namedRule struct {
+	name string
+	rule task.RewriteRule
+}
+
+// rewriteChange is what the rules do to one task, and how to undo it.
+type rewriteChange struct {
+	task  task.Task
+	rules []string // names of the rules that changed the task
+	args  []string
+	undo  []string
+}
+
+// SetRules sets the rewrite rules. If a rule does not parse, the error is
+// returned and the rules set before are kept.
+func (m *Model) SetRules(rules []config.Rule) error {
+	var named []namedRule
+	for _, r := range rules {
+		parsed, err := task.ParseRewriteRule(r.Rule)
+		if err != nil {
+			return err
+		}
+		name := r.Name
+		if name == "" {
+			name = r.Rule
+		}
+		named = append(named, namedRule{name: name, rule: parsed})
+	}
+	m.rules = named
+	return nil
+}
+
+// handleRules previews the rewrite rules on the selected task.
+func (m *Model) handleRules() (tea.Model, tea.Cmd) {
+	if len(m.rules) == 0 {
+		return m, m.showStatusTimed("No rewrite rules configured")
+	}
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	m.setRulesScope(rulesScopeTask)
+	m.rulesActive = true
+	return m, nil
+}
+
+// setRulesScope plans the rules on the tasks of scope.
+func (m *Model) setRulesScope(scope string) {
+	m.rulesScope = scope
+	m.rulesPlan = planRewrite(m.rules, m.rulesTasks(scope))
+}
+
+// rulesTasks returns the tasks of a scope in table order.
+func (m *Model) rulesTasks(scope string) []task.Task {
+	switch scope {
+	case rulesScopeTask:
+		if t, ok := m.selectedTask(); ok {
+			return []task.Task{t}
+		}
+		return nil
+	case rulesScopeSearch:
+		var tasks []task.Task
+		var seen []int
+		for _, match := range m.searchMatches {
+			if i := m.taskIndexAtRow(match.row); i >= 0 && !slices.Contains(seen, i) {
+				seen = append(seen, i)
+				tasks = append(tasks, m.tasks[i])
+			}
+		}
+		return tasks
+	}
+	return m.tasks
+}
+
+// planRewrite runs every rule on every task in order, so a rule sees the
+// changes of the rules before it.
+func planRewrite(rules []namedRule, tasks []task.Task) []rewriteChange {
+	var changes []rewriteChange
+	for _, t := range tasks {
+		c := rewriteChange{task: t}
+		rewritten := t
+		for _, r := range rules {
+			var args []string
+			if rewritten, args = r.rule.Apply(rewritten); len(args) > 0 {
+				c.rules = append(c.rules, r.name)
+			}
+		}
+		c.args = rewriteArgs(t, rewritten)
+		if len(c.args) == 0 {
+			continue
+		}
+		c.undo = rewriteArgs(rewritten, t)
+		changes = append(changes, c)
+	}
+	return changes
+}
+
+// rewriteArgs returns the modifier arguments that turn the project,
+// priority and tags of from into those of to.
+func rewriteArgs(from, to task.Task) []string {
+	var args []string
+	if from.Project != to.Project {
+		args = append(args, "project:"+to.Project)
+	}
+	if from.Priority != to.Priority {
+		args = append(args, "priority:"+to.Priority)
+	}
+	for _, tag := range from.Tags {
+		if !slices.Contains(to.Tags, tag) {
+			args = append(args, "-"+tag)
+		}
+	}
+	for _, tag := range to.Tags {
+		if !slices.Contains(from.Tags, tag) {
+			args = append(args, "+"+tag)
+		}
+	}
+	return args
+}
+
+// handleRulesMode handles keys on the preview: s, / and a pick the scope,
+// y applies the planned changes.
+func (m *Model) handleRulesMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "q":
+		m.rulesActive = false
+		m.rulesPlan = nil
+	case "s":
+		m.setRulesScope(rulesScopeTask)
+	case "/":
+		m.setRulesScope(rulesScopeSearch)
+	case "a":
+		m.setRulesScope(rulesScopeView)
+	case "y", "enter":
+		return m, m.applyRewrite()
+	}
+	return m, nil
+}
+
+// applyRewrite runs the planned changes as a whole, rolling the finished
+// ones back on a failure, and pushes them as a single undo step.
+func (m *Model) applyRewrite() tea.Cmd {
+	changes := m.rulesPlan
+	if len(changes) == 0 {
+		return m.showStatusTimed("Nothing to rewrite")
+	}
+	m.rulesActive = false
+	m.rulesPlan = nil
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	var restores []undoRestore
+	for _, c := range changes {
+		if err := m.taskwarriorClient().ModifyUUIDContext(ctx, c.task.UUID, c.args); err != nil {
+			err = fmt.Errorf("modifying task %d: %w", c.task.ID, err)
+			if rollbackErr := m.rollbackUndoRestores(restores); rollbackErr != nil {
+				err = fmt.Errorf("%w; rollback failed: %w", err, rollbackErr)
+			}
+			m.reloadAndReport()
+			return m.showErrorTimed(err)
+		}
+		restores = append(restores, undoRestore{uuid: c.task.UUID, args: c.undo})
+	}
+	m.pushUndoAction("rewrite", restores)
+	if !m.reloadAndReport() {
+		return nil
+	}
+	return m.showStatusTimed(fmt.Sprintf("Rewrote %d task(s) (U undoes)", len(changes)))
+}
+
+func (m *Model) renderRulesScreen() string {
+	dim := lipgloss.NewStyle().Foreground(lipgloss.Color("246"))
+	line := lipgloss.NewStyle().MaxWidth(m.fullScreenWidth())
+	rows := m.fullScreenRows()
+
+	var lines []string
+	if len(m.rulesPlan) == 0 {
+		lines = append(lines, "  no rule changes these tasks")
+	}
+	for i, c := range m.rulesPlan {
+		if len(lines) == rows-1 && i < len(m.rulesPlan)-1 {
+			lines = append(lines, fmt.Sprintf("  … %d more", len(m.rulesPlan)-i))
+			break
+		}
+		change := fmt.Sprintf("  %4d  %-30s %s ", c.task.ID, strings.Join(c.args, " "), c.task.Description)
+		lines = append(lines, line.Render(change+dim.Render("("+strings.Join(c.rules, ", ")+")")))
+	}
+	return m.renderFullScreen(fmt.Sprintf("Rewrite rules on %s: %d change(s), dry run", m.rulesScope, len(m.rulesPlan)), lines,
+		"s selected task | / search matches | a all listed | y/Enter apply | Esc close")
+}
@@ -0,0 +1,70 @@
+package ui
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/config"
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestRewriteRules(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "a", Description: "PR 7: login", Status: "pending", Tags: []string{"inbox"}},
+		{ID: 2, UUID: "b", Description: "buy milk", Status: "pending", Tags: []string{"inbox", "home"}},
+		{ID: 3, UUID: "c", Description: "PR 8: docs", Status: "pending", Tags: []string{"inbox"}},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.windowHeight = 20
+	if err := m.SetRules([]config.Rule{
+		{Name: "review PRs", Rule: `tag:inbox + desc~"^PR " -> project:review -tag:inbox`},
+		{Rule: "tag:home -> project:home -tag:home -tag:inbox"},
+	}); err != nil {
+		t.Fatalf("SetRules: %v", err)
+	}
+	if err := m.SetRules([]config.Rule{{Rule: "tag:home project:home"}}); err == nil || len(m.rules) != 2 {
+		t.Fatalf("expected an invalid rule to fail and keep the rules, got %v and %d rules", err, len(m.rules))
+	}
+
+	m.Update(tea.KeyPressMsg{Code: 'w', Mod: tea.ModCtrl})
+	if !m.rulesActive || m.rulesScope != rulesScopeTask || len(m.rulesPlan) != 1 {
+		t.Fatalf("expected a plan for the selected task, got %+v", m.rulesPlan)
+	}
+
+	m.searchRegex = regexp.MustCompile("PR")
+	m.tbl.SetRows(m.buildTaskRows(m.tasks))
+	m.Update(tea.KeyPressMsg{Code: '/', Text: "/"})
+	if len(m.rulesPlan) != 2 || m.rulesPlan[1].task.ID != 3 {
+		t.Fatalf("expected the search matches planned, got %+v", m.rulesPlan)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	screen := m.renderRulesScreen()
+	for _, want := range []string{"all listed tasks: 3 change(s), dry run", "project:home -inbox -home", "(review PRs)"} {
+		if !strings.Contains(screen, want) {
+			t.Fatalf("preview lacks %q:\n%s", want, screen)
+		}
+	}
+	if len(fake.modifications) != 0 {
+		t.Fatal("expected a dry run before confirming")
+	}
+	m.Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	want := []string{"b project:home -inbox -home", "a project:review -inbox", "c project:review -inbox"}
+	if !reflect.DeepEqual(fake.modifications, want) {
+		t.Fatalf("modifications = %q, want %q", fake.modifications, want)
+	}
+
+	fake.modifications = nil
+	m.Update(tea.KeyPressMsg{Code: 'U', Text: "U"})
+	want = []string{"b project: +inbox +home", "a project: +inbox", "c project: +inbox"}
+	if !reflect.DeepEqual(fake.modifications, want) {
+		t.Fatalf("undo modifications = %q, want %q", fake.modifications, want)
+	}
+}
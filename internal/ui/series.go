@@ -0,0 +1,370 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// seriesState holds the "P" recurring series manager screen.
+type seriesState struct {
+	seriesActive bool
+	seriesList   []task.Series
+	seriesCursor int
+	seriesPrompt int // seriesPromptNone or the field being edited
+	seriesInput  textinput.Model
+	seriesHabits bool // show the habit grids instead of the table
+}
+
+const (
+	seriesPromptNone = iota
+	seriesPromptRecur
+	seriesPromptDescription
+	seriesPromptProject
+	seriesPromptTags
+	seriesPromptPriority
+)
+
+// seriesPauseWait is the wait date that pauses a series: Taskwarrior keeps
+// generating instances, but they stay hidden until the series is resumed.
+const seriesPauseWait = "someday"
+
+// handleSeriesManager opens the recurring series manager.
+func (m *Model) handleSeriesManager() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	if err := m.loadSeries(); err != nil {
+		return m, m.showErrorTimed(fmt.Errorf("loading recurring series: %w", err))
+	}
+	m.seriesCursor = 0
+	m.seriesPrompt = seriesPromptNone
+	m.seriesHabits = false
+	m.seriesActive = true
+	return m, nil
+}
+
+// loadSeries exports every recurring template with its instances.
+func (m *Model) loadSeries() error {
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	tasks, err := m.taskwarriorClient().Export(ctx, "status.any:", "( status:recurring or parent.any: )")
+	if err != nil {
+		return err
+	}
+	m.seriesList = task.GroupSeries(tasks)
+	m.seriesCursor = min(m.seriesCursor, max(len(m.seriesList)-1, 0))
+	return nil
+}
+
+func (m *Model) closeSeriesManager() {
+	m.seriesActive = false
+	m.seriesPrompt = seriesPromptNone
+	m.seriesInput.Blur()
+	m.seriesList = nil
+}
+
+func (m *Model) selectedSeries() (task.Series, bool) {
+	if m.seriesCursor < 0 || m.seriesCursor >= len(m.seriesList) {
+		return task.Series{}, false
+	}
+	return m.seriesList[m.seriesCursor], true
+}
+
+// handleSeriesMode handles keys on the series manager. Template edits apply
+// to the template and its pending instances.
+func (m *Model) handleSeriesMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.seriesPrompt != seriesPromptNone {
+		return m.handleSeriesPrompt(msg)
+	}
+	s, ok := m.selectedSeries()
+	switch msg.String() {
+	case "esc", "q":
+		m.closeSeriesManager()
+		m.reloadAndReport()
+		return m, nil
+	case "down", "j":
+		m.seriesCursor = min(m.seriesCursor+1, max(len(m.seriesList)-1, 0))
+		return m, nil
+	case "up", "k":
+		m.seriesCursor = max(m.seriesCursor-1, 0)
+		return m, nil
+	case "h":
+		m.seriesHabits = !m.seriesHabits
+		return m, nil
+	}
+	if !ok {
+		return m, nil
+	}
+	switch msg.String() {
+	case "r":
+		m.openSeriesPrompt(seriesPromptRecur, "recur: ", s.Template.Recur)
+	case "e":
+		m.openSeriesPrompt(seriesPromptDescription, "description: ", s.Template.Description)
+	case "p":
+		m.openSeriesPrompt(seriesPromptProject, "project: ", s.Template.Project)
+	case "t":
+		m.openSeriesPrompt(seriesPromptTags, "tags: ", strings.Join(s.Template.Tags, " "))
+	case "P":
+		m.openSeriesPrompt(seriesPromptPriority, "priority (H/M/L or empty): ", s.Template.Priority)
+	case "space":
+		wait, status := seriesPauseWait, "Paused"
+		if s.Paused(time.Now()) {
+			wait, status = "", "Resumed"
+		}
+		if err := m.modifySeries(s, []string{"wait:" + wait}); err != nil {
+			return m, m.showErrorTimed(err)
+		}
+		return m, m.showStatusTimed(fmt.Sprintf("%s %q", status, s.Template.Description))
+	case "D":
+		count, _, err := m.deleteTaskWithUndo(s.Template)
+		if err != nil {
+			return m, m.showErrorTimed(err)
+		}
+		if err := m.loadSeries(); err != nil {
+			return m, m.showErrorTimed(fmt.Errorf("loading recurring series: %w", err))
+		}
+		return m, m.showStatusTimed(fmt.Sprintf("Deleted %d recurring tasks (U in the task list undoes)", count))
+	}
+	return m, nil
+}
+
+func (m *Model) openSeriesPrompt(prompt int, label, value string) {
+	m.seriesPrompt = prompt
+	m.seriesInput.Prompt = label
+	m.seriesInput.SetValue(value)
+	m.seriesInput.CursorEnd()
+	m.seriesInput.Focus()
+}
+
+func (m *Model) handleSeriesPrompt(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	onEnter := func(value string) error {
+		s, ok := m.selectedSeries()
+		if !ok {
+			return nil
+		}
+		value = strings.TrimSpace(value)
+		if m.seriesPrompt == seriesPromptRecur {
+			if err := validateRecurrence(value); err != nil {
+				return err
+			}
+			ctx, cancel := m.taskOperationContext()
+			defer cancel()
+			if err := m.taskwarriorClient().SetRecurringSeriesRecurrenceContext(ctx, s.Template.UUID, value); err != nil {
+				return err
+			}
+			return m.loadSeries()
+		}
+		args, err := seriesModifyArgs(s.Template, m.seriesPrompt, value)
+		if err != nil || len(args) == 0 {
+			return err
+		}
+		return m.modifySeries(s, args)
+	}
+	onExit := func() {
+		m.seriesPrompt = seriesPromptNone
+	}
+	return m.handleTextInput(msg, &m.seriesInput, onEnter, onExit)
+}
+
+// seriesModifyArgs turns an edited template field into modifier arguments.
+// Tags are entered as a space separated list and applied as a difference.
+func seriesModifyArgs(tmpl task.Task, prompt int, value string) ([]string, error) {
+	switch prompt {
+	case seriesPromptDescription:
+		if err := validateDescription(value); err != nil {
+			return nil, err
+		}
+		if value == tmpl.Description {
+			return nil, nil
+		}
+		return []string{"description:" + value}, nil
+	case seriesPromptProject:
+		if value == tmpl.Project {
+			return nil, nil
+		}
+		return []string{"project:" + value}, nil
+	case seriesPromptPriority:
+		value = strings.ToUpper(value)
+		if value != "" && value != "H" && value != "M" && value != "L" {
+			return nil, fmt.Errorf("priority must be H, M, L or empty")
+		}
+		if value == tmpl.Priority {
+			return nil, nil
+		}
+		return []string{"priority:" + value}, nil
+	case seriesPromptTags:
+		var tags, args []string
+		for _, tag := range strings.Fields(value) {
+			tags = append(tags, strings.TrimPrefix(tag, "+"))
+		}
+		for _, tag := range tmpl.Tags {
+			if !slices.Contains(tags, tag) {
+				args = append(args, "-"+tag)
+			}
+		}
+		for _, tag := range tags {
+			if !slices.Contains(tmpl.Tags, tag) {
+				args = append(args, "+"+tag)
+			}
+		}
+		return args, nil
+	}
+	return nil, nil
+}
+
+// modifySeries applies args to the series and reloads the screen.
+func (m *Model) modifySeries(s task.Series, args []string) error {
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	if err := m.taskwarriorClient().ModifySeriesContext(ctx, s.Template.UUID, args); err != nil {
+		return err
+	}
+	return m.loadSeries()
+}
+
+// seriesRow renders one series as a row of the manager.
+func seriesRow(s task.Series, now time.Time) string {
+	next := "-"
+	if ts, err := parseTaskDate(s.NextDue()); err == nil {
+		next = ts.Local().Format("2006-01-02")
+	}
+	rate := "-"
+	if r, ok := s.CompletionRate(); ok {
+		rate = fmt.Sprintf("%.0f%%", r*100)
+	}
+	state := "active"
+	if s.Paused(now) {
+		state = "paused"
+	}
+	return fmt.Sprintf("%-10s %-10s %7d %5d %5s  %-6s  %s",
+		s.Template.Recur, next, s.Count("pending"), s.Count("completed"), rate, state, s.Template.Description)
+}
+
+func (m *Model) renderSeriesScreen() string {
+	selected := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.theme.SelectedFG)).
+		Background(lipgloss.Color(m.theme.SelectedBG))
+
+	if m.seriesHabits {
+		return m.renderHabitScreen(selected)
+	}
+
+	lines := []string{
+		fmt.Sprintf("  %-10s %-10s %7s %5s %5s  %-6s  %s", "Recur", "Next due", "Pending", "Done", "Rate", "State", "Description"),
+	}
+	if len(m.seriesList) == 0 {
+		lines = append(lines, "  no recurring tasks")
+	}
+	// The header, the details of the selected series and the prompt take
+	// rows from the list.
+	rows := m.fullScreenRows() - 1
+	if len(m.seriesList) > 0 {
+		rows -= 2
+	}
+	if m.seriesPrompt != seriesPromptNone {
+		rows--
+	}
+	rows = max(rows, 1)
+	offset := max(m.seriesCursor-rows+1, 0)
+	now := time.Now()
+	for i := offset; i < len(m.seriesList) && i < offset+rows; i++ {
+		row := "  " + seriesRow(m.seriesList[i], now)
+		if i == m.seriesCursor {
+			row = selected.Render(row)
+		}
+		lines = append(lines, row)
+	}
+	if s, ok := m.selectedSeries(); ok {
+		tags := "-"
+		if len(s.Template.Tags) > 0 {
+			tags = "+" + strings.Join(s.Template.Tags, " +")
+		}
+		lines = append(lines, "", fmt.Sprintf("  project: %s  tags: %s  priority: %s",
+			ultraOrDash(s.Template.Project), tags, ultraOrDash(s.Template.Priority)))
+	}
+	if m.seriesPrompt != seriesPromptNone {
+		m.seriesInput.SetWidth(m.fullScreenWidth())
+		lines = append(lines, m.seriesInput.View())
+	}
+	return m.renderFullScreen(fmt.Sprintf("Recurring series: %d", len(m.seriesList)), lines,
+		"j/k select | h habits | r recurrence | e description | p project | t tags | P priority | space pause/resume | D delete | Esc close")
+}
+
+// habitMaxWeeks caps the habit grid at half a year.
+const habitMaxWeeks = 26
+
+// habitCells maps each habit state to its grid cell.
+var habitCells = map[task.HabitState]string{
+	task.HabitNone:    lipgloss.NewStyle().Foreground(lipgloss.Color("238")).Render("·"),
+	task.HabitPending: lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("□"),
+	task.HabitDeleted: lipgloss.NewStyle().Foreground(lipgloss.Color("245")).Render("×"),
+	task.HabitMissed:  lipgloss.NewStyle().Foreground(lipgloss.Color("1")).Render("■"),
+	task.HabitDone:    lipgloss.NewStyle().Foreground(lipgloss.Color("2")).Render("■"),
+}
+
+// habitGrid renders the last weeks of a series as a GitHub-style grid: one
+// column per week ending with the current one, one row per weekday from
+// Monday to Sunday. Days after today are left blank.
+func habitGrid(s task.Series, weeks int, now time.Time) []string {
+	days := s.HabitDays(now)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	start := task.WeekStart(now).AddDate(0, 0, -7*(weeks-1))
+
+	var rows []string
+	for weekday := range 7 {
+		var b strings.Builder
+		b.WriteString("  " + start.AddDate(0, 0, weekday).Format("Mon"))
+		for week := range weeks {
+			day := start.AddDate(0, 0, 7*week+weekday)
+			b.WriteString(" ")
+			if day.After(today) {
+				b.WriteString(" ")
+				continue
+			}
+			b.WriteString(habitCells[days[day.Format(time.DateOnly)]])
+		}
+		rows = append(rows, b.String())
+	}
+	return rows
+}
+
+// renderHabitScreen shows the habit grid and streaks of every series,
+// starting with the selected one.
+func (m *Model) renderHabitScreen(selected lipgloss.Style) string {
+	width := m.fullScreenWidth()
+	weeks := min(max((width-6)/2, 1), habitMaxWeeks)
+	now := time.Now()
+	rows := m.fullScreenRows()
+	lines := []string{
+		fmt.Sprintf("  %s done  %s missed  %s deleted  %s upcoming  %s nothing due",
+			habitCells[task.HabitDone], habitCells[task.HabitMissed], habitCells[task.HabitDeleted],
+			habitCells[task.HabitPending], habitCells[task.HabitNone]),
+	}
+	if len(m.seriesList) == 0 {
+		lines = append(lines, "  no recurring tasks")
+	}
+	for i := m.seriesCursor; i < len(m.seriesList) && len(lines) < rows; i++ {
+		s := m.seriesList[i]
+		current, longest := s.Streaks(now)
+		title := fmt.Sprintf("  %s (%s)  streak: %d  longest: %d", s.Template.Description, s.Template.Recur, current, longest)
+		if i == m.seriesCursor {
+			title = selected.Render(title)
+		}
+		lines = append(lines, "", title)
+		lines = append(lines, habitGrid(s, weeks, now)...)
+	}
+
+	if m.seriesPrompt != seriesPromptNone {
+		m.seriesInput.SetWidth(width)
+		lines = append(lines[:min(len(lines), rows-1)], m.seriesInput.View())
+	}
+	return m.renderFullScreen(fmt.Sprintf("Habits: %d recurring series, last %d weeks", len(m.seriesList), weeks), lines,
+		"j/k select | h series table | r recurrence | e description | space pause/resume | D delete | Esc close")
+}
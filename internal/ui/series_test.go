@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestSeriesManager(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 0, UUID: "tmpl", Description: "Water plants", Status: "recurring", Recur: "weekly", Tags: []string{"home"}},
+		{ID: 1, UUID: "i1", Parent: "tmpl", Description: "Water plants", Status: "pending", Recur: "weekly", Due: "20261020T000000Z"},
+		{ID: 0, UUID: "i0", Parent: "tmpl", Description: "Water plants", Status: "completed", Recur: "weekly"},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.windowHeight = 20
+	key := func(s string) {
+		t.Helper()
+		m.handleSeriesMode(tea.KeyPressMsg{Code: rune(s[0]), Text: s})
+	}
+
+	m.handleSeriesManager()
+	if !m.seriesActive || len(m.seriesList) != 1 {
+		t.Fatalf("expected one series, got %+v", m.seriesList)
+	}
+	if screen := m.renderSeriesScreen(); !strings.Contains(screen, "weekly     2026-10-20       1     1  100%  active  Water plants") {
+		t.Fatalf("unexpected series row:\n%s", screen)
+	}
+
+	key("t")
+	m.seriesInput.SetValue("+garden home")
+	m.handleSeriesMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	key(" ")
+	want := []string{"series tmpl +garden", "series tmpl wait:someday"}
+	if !reflect.DeepEqual(fake.modifications, want) {
+		t.Fatalf("modifications = %q, want %q", fake.modifications, want)
+	}
+
+	key("D")
+	if len(fake.statusChanges) != 3 || len(m.undoStack) != 1 {
+		t.Fatalf("expected the whole series deleted with undo, got %q", fake.statusChanges)
+	}
+	if last := fake.statusChanges[2]; !strings.HasPrefix(last, "tmpl") {
+		t.Fatalf("expected the template deleted last, got %q", fake.statusChanges)
+	}
+}
+
+func TestSeriesTableScrolls(t *testing.T) {
+	fake := &fakeTaskwarrior{}
+	for i := range 30 {
+		fake.tasks = append(fake.tasks, task.Task{UUID: fmt.Sprintf("tmpl%d", i),
+			Description: fmt.Sprintf("Chore %02d", i), Status: "recurring", Recur: "weekly"})
+	}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.windowHeight = 12
+	m.handleSeriesManager()
+	m.seriesCursor = len(m.seriesList) - 1
+
+	screen := m.renderSeriesScreen()
+	last := m.seriesList[len(m.seriesList)-1].Template.Description
+	if !strings.Contains(screen, last) || !strings.Contains(screen, "project: -") {
+		t.Fatalf("expected the selected series and its details on screen:\n%s", screen)
+	}
+	if first := m.seriesList[0].Template.Description; strings.Contains(screen, first) {
+		t.Fatalf("expected the list scrolled past %q:\n%s", first, screen)
+	}
+}
+
+func TestSeriesHabitGrid(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.Local) // a Sunday
+	due := func(day int) string {
+		return time.Date(2026, 10, day, 9, 0, 0, 0, time.Local).UTC().Format(task.DateFormat)
+	}
+	s := task.Series{Template: task.Task{UUID: "tmpl", Status: "recurring"}, Instances: []task.Task{
+		{Parent: "tmpl", Status: "completed", Due: due(12)},
+		{Parent: "tmpl", Status: "pending", Due: due(13)},
+		{Parent: "tmpl", Status: "deleted", Due: due(14)},
+	}}
+	grid := habitGrid(s, 2, now)
+	if len(grid) != 7 {
+		t.Fatalf("expected 7 weekday rows, got %d", len(grid))
+	}
+	want := []string{"Mon", habitCells[task.HabitNone], habitCells[task.HabitDone]}
+	if grid[0] != "  "+strings.Join(want, " ") {
+		t.Fatalf("monday row = %q", grid[0])
+	}
+	if !strings.HasSuffix(grid[1], habitCells[task.HabitMissed]) || !strings.HasSuffix(grid[2], habitCells[task.HabitDeleted]) {
+		t.Fatalf("unexpected grid %q", grid)
+	}
+
+	m, err := NewWithTaskwarrior(nil, "firefox", &fakeTaskwarrior{tasks: append(s.Instances, s.Template)})
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.windowHeight = 20
+	m.handleSeriesManager()
+	m.handleSeriesMode(tea.KeyPressMsg{Code: 'h', Text: "h"})
+	if screen := m.renderSeriesScreen(); !strings.Contains(screen, "streak: ") || !strings.Contains(screen, "Habits: 1 recurring series") {
+		t.Fatalf("expected the habit view:\n%s", screen)
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"os"
-	"os/exec"
 	"strings"
 	"time"
 	"unicode"
@@ -76,15 +75,7 @@ func prepareShellEditCmd(content string) tea.Cmd {
 // launchShellEditorCmd suspends the TUI and runs $EDITOR on the temp file.
 // When the editor exits it emits a shellEditDoneMsg carrying the temp path.
 func launchShellEditorCmd(tempFile string) tea.Cmd {
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vi"
-	}
-	c := exec.Command(editor, tempFile)
-	c.Stdin = os.Stdin
-	c.Stdout = os.Stdout
-	c.Stderr = os.Stderr
-	return tea.ExecProcess(c, func(err error) tea.Msg {
+	return launchEditorCmd(tempFile, func(err error) tea.Msg {
 		return shellEditDoneMsg{err: err, tempFile: tempFile}
 	})
 }
@@ -212,6 +203,9 @@ func (m *Model) handleShellCompletion(msg shellCompletionMsg) (tea.Model, tea.Cm
 	if m.shellActive {
 		m.refreshShellSuggestions()
 	}
+	if m.addFormActive {
+		m.refreshAddFormSuggestions()
+	}
 	return m, nil
 }
 
@@ -220,6 +214,9 @@ func (m *Model) handleShellOutputMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd)
 	case "esc", "q", "enter":
 		m.shellOutputVisible = false
 		return m, nil
+	case "y":
+		m.statusMsg = "Copied output to clipboard"
+		return m, tea.SetClipboard(m.shellOutputText)
 	case "up", "k":
 		m.shellOutputViewport.ScrollUp(1)
 	case "down", "j":
@@ -258,7 +255,7 @@ func (m *Model) renderShellOutputScreen() string {
 		Foreground(lipgloss.Color(m.theme.StatusFG)).
 		Background(lipgloss.Color(m.theme.StatusBG)).
 		Width(width).
-		Render("Esc/q/Enter close | j/k scroll | PgUp/PgDn page")
+		Render("Esc/q/Enter close | j/k scroll | PgUp/PgDn page | y copy")
 	return lipgloss.JoinVertical(lipgloss.Left, title, m.shellOutputViewport.View(), footer)
 }
 
@@ -274,6 +271,7 @@ func (m *Model) showShellOutput(title, output string) {
 
 	m.shellOutputVisible = true
 	m.shellOutputTitle = title
+	m.shellOutputText = output
 	m.shellOutputViewport = viewport.New(viewport.WithWidth(width), viewport.WithHeight(height))
 	m.shellOutputViewport.SetContent(strings.TrimRight(output, "\n"))
 }
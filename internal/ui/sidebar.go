@@ -0,0 +1,228 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// sidebarState holds the "[" project tree sidebar next to the table. "]"
+// moves the focus from the table to the sidebar.
+type sidebarState struct {
+	sidebarVisible bool
+	sidebarFocused bool
+	sidebarCursor  int
+	sidebarNodes   []sidebarNode
+}
+
+// sidebarNode is a line of the sidebar: a project with its depth in the
+// tree. The first line has an empty path and stands for all projects.
+type sidebarNode struct {
+	path    string
+	name    string
+	depth   int
+	pending int
+	overdue int
+}
+
+// sidebarWidth is the width of the sidebar including the gap to the table.
+const sidebarWidth = 28
+
+// handleToggleSidebar shows and focuses the sidebar, or hides it.
+func (m *Model) handleToggleSidebar() (tea.Model, tea.Cmd) {
+	if m.sidebarVisible {
+		m.sidebarVisible = false
+		m.sidebarFocused = false
+		m.resizeTable()
+		return m, nil
+	}
+	if err := m.loadSidebar(); err != nil {
+		return m, m.showErrorTimed(fmt.Errorf("loading projects: %w", err))
+	}
+	m.sidebarVisible = true
+	m.sidebarFocused = true
+	m.sidebarCursor = max(slices.IndexFunc(m.sidebarNodes, func(n sidebarNode) bool {
+		return n.path == m.projectFilter()
+	}), 0)
+	m.resizeTable()
+	return m, nil
+}
+
+// resizeTable fits the table into the window next to the sidebar.
+func (m *Model) resizeTable() {
+	width := m.windowWidth
+	if width == 0 {
+		width = 80
+	}
+	if m.sidebarVisible {
+		width = max(width-sidebarWidth, 20)
+	}
+	m.tbl.SetWidth(width)
+	m.computeColumnWidths()
+}
+
+// screenWidth is the width of the table plus the sidebar, if shown.
+func (m *Model) screenWidth() int {
+	if m.sidebarVisible {
+		return m.tbl.Width() + sidebarWidth
+	}
+	return m.tbl.Width()
+}
+
+// loadSidebar counts the pending tasks of every project, regardless of the
+// table's filter.
+func (m *Model) loadSidebar() error {
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	tasks, err := m.taskwarriorClient().Export(ctx, "status:pending")
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	all := sidebarNode{name: "all projects"}
+	for _, t := range tasks {
+		if t.Status != "pending" {
+			continue
+		}
+		all.pending++
+		if ts, err := parseTaskDate(t.Due); err == nil && ts.Before(now) {
+			all.overdue++
+		}
+	}
+	nodes := []sidebarNode{all}
+	var walk func([]*task.ProjectNode, int)
+	walk = func(level []*task.ProjectNode, depth int) {
+		for _, n := range level {
+			nodes = append(nodes, sidebarNode{path: n.Path, name: n.Name, depth: depth, pending: n.Pending, overdue: n.Overdue})
+			walk(n.Children, depth+1)
+		}
+	}
+	walk(task.ProjectTree(tasks, now), 0)
+	m.sidebarNodes = nodes
+	m.sidebarCursor = min(m.sidebarCursor, len(nodes)-1)
+	return nil
+}
+
+// projectFilter returns the project of a "project:" filter, if any.
+func (m *Model) projectFilter() string {
+	for _, f := range m.filters {
+		if project, ok := cutProjectFilter(f); ok {
+			return project
+		}
+	}
+	return ""
+}
+
+func cutProjectFilter(filter string) (string, bool) {
+	for _, prefix := range []string{"project:", "proj:", "pro:"} {
+		if project, ok := strings.CutPrefix(filter, prefix); ok {
+			return project, true
+		}
+	}
+	return "", false
+}
+
+// filterByProject replaces the project filter of the table with path, or
+// removes it for an empty path, and reloads.
+func (m *Model) filterByProject(path string) error {
+	prev := m.filters
+	filters := slices.DeleteFunc(slices.Clone(m.filters), func(f string) bool {
+		_, ok := cutProjectFilter(f)
+		return ok
+	})
+	if path != "" {
+		filters = append(filters, "project:"+path)
+	}
+	m.filters = filters
+	if err := m.reload(); err != nil {
+		m.filters = prev
+		return fmt.Errorf("filter error: %w", err)
+	}
+	return nil
+}
+
+// handleSidebarKey handles the keys of the focused sidebar and reports
+// whether it did; other keys fall through to the table.
+func (m *Model) handleSidebarKey(msg tea.KeyPressMsg) (bool, tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "down", "j":
+		m.sidebarCursor = min(m.sidebarCursor+1, len(m.sidebarNodes)-1)
+	case "up", "k":
+		m.sidebarCursor = max(m.sidebarCursor-1, 0)
+	case "home", "g":
+		m.sidebarCursor = 0
+	case "end", "G":
+		m.sidebarCursor = max(len(m.sidebarNodes)-1, 0)
+	case "enter", "space":
+		if m.sidebarCursor < 0 || m.sidebarCursor >= len(m.sidebarNodes) {
+			return true, m, nil
+		}
+		if err := m.filterByProject(m.sidebarNodes[m.sidebarCursor].path); err != nil {
+			return true, m, m.showErrorTimed(err)
+		}
+	case "tab", "right", "l", "esc", "]":
+		m.sidebarFocused = false
+	case "[":
+		model, cmd := m.handleToggleSidebar()
+		return true, model, cmd
+	default:
+		return false, m, nil
+	}
+	return true, m, nil
+}
+
+// handleFocusSidebar moves the focus from the table to the sidebar.
+func (m *Model) handleFocusSidebar() (tea.Model, tea.Cmd) {
+	if m.sidebarVisible {
+		m.sidebarFocused = true
+	}
+	return m, nil
+}
+
+// renderSidebar renders the project tree in height lines. Counts are pending
+// tasks, followed by the overdue ones in red.
+func (m *Model) renderSidebar(height int) string {
+	width := sidebarWidth - 1
+	selected := lipgloss.NewStyle().
+		Foreground(lipgloss.Color(m.theme.SelectedFG)).
+		Background(lipgloss.Color(m.theme.SelectedBG))
+	overdue := lipgloss.NewStyle().Foreground(lipgloss.Color(m.theme.OverdueBG))
+	active := m.projectFilter()
+
+	offset := max(m.sidebarCursor-height+1, 0)
+	var lines []string
+	for i := offset; i < len(m.sidebarNodes) && len(lines) < height; i++ {
+		n := m.sidebarNodes[i]
+		marker := " "
+		if n.path == active {
+			marker = "•"
+		}
+		counts := fmt.Sprintf("%d", n.pending)
+		plainCounts := counts
+		if n.overdue > 0 {
+			plainCounts += fmt.Sprintf(" %d!", n.overdue)
+			counts += " " + overdue.Render(fmt.Sprintf("%d!", n.overdue))
+		}
+		label := ansi.Truncate(marker+strings.Repeat("  ", n.depth)+n.name, max(width-len(plainCounts)-1, 1), "…")
+		line := label + strings.Repeat(" ", max(width-ansi.StringWidth(label)-len(plainCounts), 1)) + counts
+		if i == m.sidebarCursor {
+			style := lipgloss.NewStyle().Bold(true)
+			if m.sidebarFocused {
+				style = selected
+			}
+			line = style.Render(label + strings.Repeat(" ", max(width-ansi.StringWidth(label)-len(plainCounts), 1)) + plainCounts)
+		}
+		lines = append(lines, line)
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return lipgloss.NewStyle().Width(width).MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
@@ -0,0 +1,78 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/charmbracelet/x/ansi"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestProjectSidebar(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "a", Description: "alpha", Status: "pending", Project: "work.client.api", Due: "20200101T000000Z"},
+		{ID: 2, UUID: "b", Description: "beta", Status: "pending", Project: "work.client"},
+		{ID: 3, UUID: "c", Description: "gamma", Status: "pending", Project: "home"},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.filters = []string{"+next", "proj:home"}
+	m.Update(tea.WindowSizeMsg{Width: 100, Height: 20})
+
+	m.Update(tea.KeyPressMsg{Code: '[', Text: "["})
+	if !m.sidebarVisible || !m.sidebarFocused {
+		t.Fatal("expected the sidebar to be shown and focused")
+	}
+	if m.tbl.Width() != 100-sidebarWidth {
+		t.Fatalf("table width = %d, want %d", m.tbl.Width(), 100-sidebarWidth)
+	}
+	var paths []string
+	for _, n := range m.sidebarNodes {
+		paths = append(paths, n.path)
+	}
+	if want := []string{"", "home", "work", "work.client", "work.client.api"}; !reflect.DeepEqual(paths, want) {
+		t.Fatalf("sidebar paths = %q, want %q", paths, want)
+	}
+	if m.sidebarCursor != 1 {
+		t.Fatalf("expected the cursor on the filtered project, got %d", m.sidebarCursor)
+	}
+	if n := m.sidebarNodes[3]; n.depth != 1 || n.pending != 2 || n.overdue != 1 {
+		t.Fatalf("work.client node = %+v", n)
+	}
+	view := ansi.Strip(m.View().Content)
+	if !strings.Contains(view, "client") || !strings.Contains(view, "2 1!") {
+		t.Fatalf("expected the tree with counts in the view:\n%s", view)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if want := []string{"+next", "project:work.client"}; !reflect.DeepEqual(m.filters, want) {
+		t.Fatalf("filters = %q, want %q", m.filters, want)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: tea.KeyTab})
+	if m.sidebarFocused {
+		t.Fatal("expected tab to focus the table")
+	}
+	m.Update(tea.KeyPressMsg{Code: 'j', Text: "j"})
+	if m.sidebarCursor != 3 {
+		t.Fatal("expected j to move the table, not the sidebar")
+	}
+	m.Update(tea.KeyPressMsg{Code: ']', Text: "]"})
+	m.Update(tea.KeyPressMsg{Code: 'g', Text: "g"})
+	m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if want := []string{"+next"}; !reflect.DeepEqual(m.filters, want) {
+		t.Fatalf("filters = %q, want %q", m.filters, want)
+	}
+
+	m.Update(tea.KeyPressMsg{Code: '[', Text: "["})
+	if m.sidebarVisible || m.tbl.Width() != 100 {
+		t.Fatalf("expected the sidebar hidden and the table at full width, got %d", m.tbl.Width())
+	}
+}
@@ -0,0 +1,49 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/report"
+)
+
+// handleStandup opens the standup period prompt, pre-filled with
+// "yesterday".
+func (m *Model) handleStandup() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	m.standupEditing = true
+	m.standupInput.SetValue("yesterday")
+	m.standupInput.CursorEnd()
+	m.standupInput.Focus()
+	m.updateTableHeight()
+	return m, nil
+}
+
+// handleStandupMode builds the standup report for the entered period and
+// shows it in the output panel, where y copies the Markdown.
+func (m *Model) handleStandupMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	onEnter := func(value string) error {
+		since, err := report.ParseSince(value, time.Now())
+		if err != nil {
+			return err
+		}
+		ctx, cancel := m.taskOperationContext()
+		defer cancel()
+		standup, err := report.LoadStandup(ctx, m.taskwarriorClient(), since)
+		if err != nil {
+			return fmt.Errorf("loading standup: %w", err)
+		}
+		title := fmt.Sprintf("Standup since %s: %d tasks", since.Format("Mon 2006-01-02"), standup.Count())
+		m.showShellOutput(title, standup.Markdown())
+		return nil
+	}
+
+	onExit := func() {
+		m.standupEditing = false
+	}
+
+	return m.handleTextInput(msg, &m.standupInput, onEnter, onExit)
+}
@@ -0,0 +1,58 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestStandupReport(t *testing.T) {
+	recent := time.Now().UTC().Format(task.DateFormat)
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "u1", Description: "Review PR", Project: "dev", Status: "pending", Start: recent, Modified: recent},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+
+	m.handleStandup()
+	if !m.standupEditing || m.standupInput.Value() != "yesterday" {
+		t.Fatalf("expected the standup prompt pre-filled with yesterday")
+	}
+	m.handleStandupMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.standupEditing || !m.shellOutputVisible {
+		t.Fatalf("expected the report in the output panel")
+	}
+	last := fake.exportFilters[len(fake.exportFilters)-1]
+	if len(last) != 2 || last[0] != "status.any:" || !strings.HasPrefix(last[1], "modified.after:") {
+		t.Fatalf("unexpected export filters %q", last)
+	}
+	if !strings.Contains(m.shellOutputText, "## dev\n\nStarted:\n- [ ] Review PR") {
+		t.Fatalf("unexpected report:\n%s", m.shellOutputText)
+	}
+	if !strings.Contains(m.shellOutputTitle, "1 tasks") {
+		t.Fatalf("unexpected title %q", m.shellOutputTitle)
+	}
+
+	if _, cmd := m.handleShellOutputMode(tea.KeyPressMsg{Code: 'y', Text: "y"}); cmd == nil {
+		t.Fatalf("expected y to copy the report")
+	}
+}
+
+func TestStandupInvalidDateKeepsPrompt(t *testing.T) {
+	m, err := NewWithTaskwarrior(nil, "firefox", &fakeTaskwarrior{})
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.handleStandup()
+	m.standupInput.SetValue("someday")
+	m.handleStandupMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if !m.standupEditing || m.shellOutputVisible {
+		t.Fatalf("expected the prompt to stay open")
+	}
+}
@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/google/shlex"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// nestSubtasks reorders tasks so that every subtask directly follows its
+// parent and returns the nesting depth of each moved task by UUID. A
+// subtask is a task another visible task depends on; when several parents
+// depend on it, it is placed under the first one in sort order. Tasks caught
+// in a dependency cycle keep their original position.
+func nestSubtasks(tasks []task.Task) ([]task.Task, map[string]int) {
+	byUUID := make(map[string]int, len(tasks))
+	for i, t := range tasks {
+		if t.UUID != "" {
+			byUUID[t.UUID] = i
+		}
+	}
+
+	parentOf := make(map[int]int)
+	children := make(map[int][]int)
+	for i, t := range tasks {
+		for _, uuid := range t.Depends {
+			c, ok := byUUID[uuid]
+			if !ok || c == i {
+				continue
+			}
+			if _, taken := parentOf[c]; taken {
+				continue
+			}
+			parentOf[c] = i
+			children[i] = append(children[i], c)
+		}
+	}
+	if len(parentOf) == 0 {
+		return tasks, nil
+	}
+	for _, cs := range children {
+		slices.Sort(cs)
+	}
+
+	out := make([]task.Task, 0, len(tasks))
+	depth := make(map[string]int)
+	placed := make([]bool, len(tasks))
+	var place func(i, d int)
+	place = func(i, d int) {
+		if placed[i] {
+			return
+		}
+		placed[i] = true
+		out = append(out, tasks[i])
+		if d > 0 {
+			depth[tasks[i].UUID] = d
+		}
+		for _, c := range children[i] {
+			place(c, d+1)
+		}
+	}
+	for i := range tasks {
+		if _, isChild := parentOf[i]; !isChild {
+			place(i, 0)
+		}
+	}
+	// Whatever is left is only reachable through a cycle.
+	for i := range tasks {
+		place(i, 0)
+	}
+	return out, depth
+}
+
+// subtaskPrefix returns the indentation marker drawn before the description
+// of a nested subtask, or "" for top-level tasks.
+func (m *Model) subtaskPrefix(t task.Task) string {
+	d := m.subtaskDepth[t.UUID]
+	if d == 0 {
+		return ""
+	}
+	return strings.Repeat("  ", d-1) + "↳ "
+}
+
+// handleAddSubtask opens the subtask prompt for the selected task.
+func (m *Model) handleAddSubtask() (tea.Model, tea.Cmd) {
+	parent, ok := m.selectedTask()
+	if !ok {
+		return m, nil
+	}
+
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	m.subtaskAdding = true
+	m.subtaskParentID = parent.ID
+	m.subtaskInput.Prompt = fmt.Sprintf("subtask of %d: ", parent.ID)
+	m.subtaskInput.SetValue("")
+	m.subtaskInput.Focus()
+	m.updateTableHeight()
+	return m, nil
+}
+
+// subtaskArgs returns the "task add" arguments for a subtask of parent:
+// the parent's project and tags followed by the words the user typed, so
+// modifiers entered in the prompt override the inherited ones.
+func subtaskArgs(parent task.Task, line string) ([]string, error) {
+	fields, err := shlex.Split(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("description cannot be empty")
+	}
+	var args []string
+	if parent.Project != "" {
+		args = append(args, "project:"+parent.Project)
+	}
+	for _, tag := range parent.Tags {
+		args = append(args, "+"+tag)
+	}
+	return append(args, fields...), nil
+}
+
+// latestTask returns the task added last. It is looked up with +LATEST
+// rather than in the table, so it is found even when the current filter
+// does not list it.
+func (m *Model) latestTask() (task.Task, error) {
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	tasks, err := m.taskwarriorClient().Export(ctx, "+LATEST")
+	if err != nil {
+		return task.Task{}, fmt.Errorf("finding the new task: %w", err)
+	}
+	if len(tasks) == 0 {
+		return task.Task{}, fmt.Errorf("finding the new task: none found")
+	}
+	return tasks[len(tasks)-1], nil
+}
+
+// handleSubtaskMode creates the subtask, makes the parent depend on it and
+// selects the new task. The prompt closes once "task add" succeeded, so a
+// failing dependency link cannot lead to a duplicate subtask on retry.
+func (m *Model) handleSubtaskMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	var (
+		added    bool
+		parentID int
+	)
+	onEnter := func(value string) error {
+		parent := m.taskByID(m.subtaskParentID)
+		if parent == nil {
+			return fmt.Errorf("parent task %d is no longer listed", m.subtaskParentID)
+		}
+		args, err := subtaskArgs(*parent, value)
+		if err != nil {
+			return err
+		}
+		parentID = parent.ID
+
+		ctx, cancel := m.taskOperationContext()
+		defer cancel()
+		if err := m.taskwarriorClient().AddArgsContext(ctx, args); err != nil {
+			return err
+		}
+		added = true
+		return nil
+	}
+
+	onExit := func() {
+		m.subtaskAdding = false
+		m.subtaskParentID = 0
+	}
+
+	model, cmd := m.handleTextInput(msg, &m.subtaskInput, onEnter, onExit)
+	if !added {
+		return model, cmd
+	}
+
+	child, err := m.latestTask()
+	if err == nil {
+		ctx, cancel := m.taskOperationContext()
+		err = m.taskwarriorClient().AddDependencyContext(ctx, parentID, child.UUID)
+		cancel()
+	}
+	if err != nil {
+		m.showError(fmt.Errorf("linking subtask: %w", err))
+	}
+	if !m.reloadAndReport() || err != nil {
+		return m, nil
+	}
+	if m.taskByID(child.ID) == nil {
+		m.statusMsg = "Subtask added and linked; the current filter does not list it"
+		return m, nil
+	}
+	m.selectTaskByID(child.ID)
+	return m, m.startBlink(child.ID, false)
+}
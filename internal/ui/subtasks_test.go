@@ -0,0 +1,140 @@
+package ui
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func taskUUIDs(tasks []task.Task) []string {
+	uuids := make([]string, len(tasks))
+	for i, t := range tasks {
+		uuids[i] = t.UUID
+	}
+	return uuids
+}
+
+func TestNestSubtasks(t *testing.T) {
+	tasks := []task.Task{
+		{UUID: "child"},
+		{UUID: "other"},
+		{UUID: "parent", Depends: task.Dependencies{"grandchild-parent", "child", "missing"}},
+		{UUID: "grandchild-parent", Depends: task.Dependencies{"grandchild"}},
+		{UUID: "grandchild"},
+		{UUID: "cycle-a", Depends: task.Dependencies{"cycle-b"}},
+		{UUID: "cycle-b", Depends: task.Dependencies{"cycle-a"}},
+	}
+
+	got, depth := nestSubtasks(tasks)
+	want := []string{"other", "parent", "child", "grandchild-parent", "grandchild", "cycle-a", "cycle-b"}
+	if !reflect.DeepEqual(taskUUIDs(got), want) {
+		t.Fatalf("order = %q, want %q", taskUUIDs(got), want)
+	}
+	wantDepth := map[string]int{"child": 1, "grandchild-parent": 1, "grandchild": 2, "cycle-b": 1}
+	if !reflect.DeepEqual(depth, wantDepth) {
+		t.Fatalf("depth = %v, want %v", depth, wantDepth)
+	}
+
+	plain := []task.Task{{UUID: "a"}, {UUID: "b"}}
+	if got, depth := nestSubtasks(plain); !reflect.DeepEqual(got, plain) || depth != nil {
+		t.Fatalf("expected tasks without dependencies to be untouched")
+	}
+}
+
+// TestAddSubtask creates a subtask via the prompt and checks that it
+// inherits project and tags, is linked from the parent, and is shown
+// indented directly under it.
+func TestAddSubtask(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "parent", Description: "Plan trip", Project: "travel", Tags: []string{"family"}, Status: "pending", Urgency: 5},
+		{ID: 2, UUID: "other", Description: "Other", Status: "pending", Urgency: 3},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+
+	m.selectTaskByID(1)
+	m.handleAddSubtask()
+	if !m.subtaskAdding || !strings.Contains(m.subtaskInput.Prompt, "subtask of 1") {
+		t.Fatalf("expected subtask prompt for task 1, got %q", m.subtaskInput.Prompt)
+	}
+	m.subtaskInput.SetValue("Book hotel +urgent")
+	m.handleSubtaskMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	wantArgs := []string{"project:travel", "+family", "Book", "hotel", "+urgent"}
+	if len(fake.addArgs) != 1 || !reflect.DeepEqual(fake.addArgs[0], wantArgs) {
+		t.Fatalf("unexpected add args %q", fake.addArgs)
+	}
+	if m.subtaskAdding {
+		t.Fatalf("expected prompt to close")
+	}
+	if deps := fake.tasks[0].Depends; len(deps) != 1 || deps[0] != "fake-3" {
+		t.Fatalf("expected parent to depend on the new task, got %q", deps)
+	}
+	row := m.taskIndexByID(1)
+	if row < 0 || row+1 >= len(m.tasks) || m.tasks[row+1].UUID != "fake-3" {
+		t.Fatalf("expected subtask directly under its parent, got %q", taskUUIDs(m.tasks))
+	}
+	if sel := m.getTaskAtCursor(); sel == nil || sel.UUID != "fake-3" {
+		t.Fatalf("expected the subtask to be selected, got %+v", sel)
+	}
+	if m.subtaskPrefix(m.tasks[row+1]) == "" || m.subtaskPrefix(m.tasks[row]) != "" {
+		t.Fatalf("expected only the subtask to be indented")
+	}
+}
+
+// unlistedNewTasksFake lists only the first tasks, as if the current filter
+// did not match the tasks added later; +LATEST still finds them.
+type unlistedNewTasksFake struct {
+	*fakeTaskwarrior
+	listed int
+}
+
+func (f *unlistedNewTasksFake) Export(ctx context.Context, filters ...string) ([]task.Task, error) {
+	tasks, err := f.fakeTaskwarrior.Export(ctx, filters...)
+	if slices.Contains(filters, "+LATEST") || len(tasks) <= f.listed {
+		return tasks, err
+	}
+	return tasks[:f.listed], err
+}
+
+func TestAddSubtaskOutsideFilter(t *testing.T) {
+	fake := &unlistedNewTasksFake{fakeTaskwarrior: &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "parent", Description: "Plan trip", Status: "pending"},
+	}}, listed: 1}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.handleAddSubtask()
+	m.subtaskInput.SetValue("Book hotel")
+	m.handleSubtaskMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if deps := fake.tasks[0].Depends; len(deps) != 1 || deps[0] != "fake-2" {
+		t.Fatalf("expected parent to depend on the unlisted subtask, got %q", deps)
+	}
+	if !strings.Contains(m.statusMsg, "linked") {
+		t.Fatalf("unexpected status %q", m.statusMsg)
+	}
+}
+
+func TestAddSubtaskRejectsEmptyDescription(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{{ID: 1, UUID: "parent", Description: "Parent", Status: "pending"}}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.handleAddSubtask()
+	m.subtaskInput.SetValue("   ")
+	m.handleSubtaskMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if len(fake.addArgs) != 0 || !m.subtaskAdding {
+		t.Fatalf("expected empty subtask to be rejected with the prompt still open")
+	}
+}
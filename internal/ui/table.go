@@ -5,7 +5,6 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"reflect"
 	"regexp"
 	"strconv"
@@ -15,6 +14,7 @@ import (
 
 	"github.com/charmbracelet/x/ansi"
 
+	"charm.land/bubbles/v2/textarea"
 	"charm.land/bubbles/v2/textinput"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
@@ -22,6 +22,7 @@ import (
 
 	"codeberg.org/snonux/tasksamurai/internal"
 	atable "codeberg.org/snonux/tasksamurai/internal/atable"
+	"codeberg.org/snonux/tasksamurai/internal/config"
 	"codeberg.org/snonux/tasksamurai/internal/task"
 	uihelp "codeberg.org/snonux/tasksamurai/internal/ui/help"
 )
@@ -75,6 +76,7 @@ const (
 type undoRestore struct {
 	uuid   string
 	status string
+	args   []string // modification that restores the task; replaces status when set
 }
 
 type undoAction struct {
@@ -84,23 +86,32 @@ type undoAction struct {
 
 // blinkState holds row-level blink animation state for the task table.
 // A blink cycles the selected row's highlight on/off after a modification.
+// blinkTasks blinks several rows along, e.g. the tasks a refresh added or
+// changed; unlike blinkID they do not block key input.
 type blinkState struct {
-	blinkID       int  // task ID currently being blinked (0 = none)
-	blinkRow      int  // row index in the table (-1 if not found)
-	blinkOn       bool // whether the highlight is currently inverted
-	blinkCount    int  // number of blink cycles completed so far
-	blinkMarkDone bool // whether to mark the task done after blinking
-	blinkEnabled  bool // when false, skip animation and complete immediately
+	blinkID       int             // task ID currently being blinked (0 = none)
+	blinkRow      int             // row index in the table (-1 if not found)
+	blinkTasks    map[string]bool // UUIDs of further tasks being blinked
+	blinkOn       bool            // whether the highlight is currently inverted
+	blinkCount    int             // number of blink cycles completed so far
+	blinkMarkDone bool            // whether to mark the task done after blinking
+	blinkEnabled  bool            // when false, skip animation and complete immediately
+	blinkTicking  bool            // a row blink tick is pending
 }
 
 // autoRefreshState drives the periodic background reload of the task list.
 // When autoRefresh is enabled the model reloads tasks every
 // autoRefreshInterval, as if the user pressed "space". Reloads are skipped
 // while any input/editing mode is active so user input is never clobbered.
+// In adaptive mode the effective delay (autoRefreshCurrent) backs off while
+// exports stay unchanged and speeds up again once a change is detected.
 type autoRefreshState struct {
 	autoRefresh         bool          // whether periodic reload is enabled
-	autoRefreshInterval time.Duration // delay between automatic reloads
+	autoRefreshInterval time.Duration // configured (base) delay between automatic reloads
 	autoRefreshGen      int           // generation token; stale ticks are dropped
+	autoRefreshAdaptive bool          // back off/speed up based on export changes
+	autoRefreshCurrent  time.Duration // effective delay in adaptive mode (0 = base)
+	autoRefreshHash     uint64        // content hash of the last observed task list
 }
 
 // searchState holds task-table and help-screen search state.
@@ -133,6 +144,9 @@ type detailViewState struct {
 	detailBlinkField            int  // field currently blinking (-1 = none)
 	detailBlinkOn               bool // whether the blink is currently on
 	detailBlinkCount            int  // number of blink cycles completed so far
+	detailHistoryVisible        bool // History tab shown instead of the fields
+	detailHistory               []task.HistoryEntry
+	detailHistoryOffset         int // first History line shown
 }
 
 // ultraState holds the state for the ultra mode task list and its search UI.
@@ -172,6 +186,7 @@ type shellState struct {
 	shellHistory        []string
 	shellOutputVisible  bool
 	shellOutputTitle    string
+	shellOutputText     string
 	shellOutputViewport viewport.Model
 	shellCompletion     task.CompletionSources
 	shellCompletionLoad bool
@@ -194,9 +209,10 @@ type editState struct {
 	tagsID      int
 	tagsInput   textinput.Model
 
-	dueEditing bool
-	dueID      int
-	dueDate    time.Time
+	dueEditing  bool
+	dueID       int
+	dueDate     time.Time
+	dueWaitUUID string // set the wait date of this task instead (see inbox.go)
 
 	recurEditing bool
 	recurID      int
@@ -211,8 +227,33 @@ type editState struct {
 	filterEditing bool
 	filterInput   textinput.Model
 
-	addingTask bool
-	addInput   textinput.Model
+	addingTask     bool
+	addInput       textinput.Model
+	addAnnotations []string // annotations added to the next task created via addInput
+	quickAdd       bool     // parse addInput as natural language (see quickadd.go)
+
+	templatePicking bool
+	templateInput   textinput.Model
+
+	subtaskAdding   bool
+	subtaskParentID int
+	subtaskInput    textinput.Model
+
+	importPathEditing bool
+	importPathInput   textinput.Model
+
+	exportPathEditing bool
+	exportPathInput   textinput.Model
+
+	standupEditing bool
+	standupInput   textinput.Model
+
+	addFormActive bool
+	addFormFocus  int
+	addFormInputs [addFormFieldCount]textinput.Model
+
+	refreshIntervalEditing bool
+	refreshIntervalInput   textinput.Model
 
 	prioritySelecting bool
 	priorityID        int
@@ -239,9 +280,23 @@ type Model struct {
 	helpState        // help-screen viewport state
 	shellState       // Taskwarrior command prompt and output panel
 	editState        // inline field editing (see editState)
+	bulkEditState    // $EDITOR bulk edit review screen (see bulkedit.go)
+	batchAddState    // multi-line batch add screen (see batchadd.go)
+	importState      // import preview screen (see importer.go)
+	urgencyTuneState // urgency coefficient tuning screen (see urgencytune.go)
+	seriesState      // recurring series manager (see series.go)
+	heatmapState     // completion heatmap screen (see heatmap.go)
+	boardState       // kanban board (see board.go)
+	groupState       // group-by mode of the table (see groups.go)
+	sidebarState     // project tree sidebar (see sidebar.go)
+	refactorState    // project and tag manager (see refactor.go)
+	rulesState       // rewrite rule preview (see rules.go)
+	enrichState      // add prompt suggestions (see enrich.go)
+	inboxState       // inbox processing wizard (see inbox.go)
 
 	cellExpanded bool
 
+	windowWidth  int
 	windowHeight int
 
 	idWidth    int
@@ -259,10 +314,13 @@ type Model struct {
 	inProgress int
 	due        int
 
-	filters    []string
-	tasks      []task.Task
-	undoStack  []undoAction
-	browserCmd string
+	filters []string
+	tasks   []task.Task
+	// subtaskDepth maps the UUID of each nested subtask to its indentation
+	// level under its parent (see nestSubtasks).
+	subtaskDepth map[string]int
+	undoStack    []undoAction
+	browserCmd   string
 	// youtubeBrowserCmd, when non-empty, overrides browserCmd for YouTube
 	// links opened with the "o" key. This lets the user route videos to a
 	// browser better suited for them (e.g. chromium) while keeping the
@@ -270,6 +328,7 @@ type Model struct {
 	youtubeBrowserCmd string
 	agentFilterHotkey string
 	taskwarrior       task.Taskwarrior
+	templates         []config.Template
 
 	theme        Theme
 	defaultTheme Theme
@@ -336,6 +395,7 @@ type descriptionTempFile interface {
 
 type reloadData struct {
 	tasks          []task.Task
+	subtaskDepth   map[string]int
 	ultraFilterIDs []int
 }
 
@@ -413,17 +473,7 @@ func editDescriptionCmd(description string) tea.Cmd {
 }
 
 func launchDescriptionEditorCmd(tmpPath string) tea.Cmd {
-	editor := os.Getenv("EDITOR")
-	if editor == "" {
-		editor = "vi"
-	}
-
-	c := exec.Command(editor, tmpPath)
-	c.Stdin = os.Stdin
-	c.Stdout = os.Stdout
-	c.Stderr = os.Stderr
-
-	return tea.ExecProcess(c, func(err error) tea.Msg {
+	return launchEditorCmd(tmpPath, func(err error) tea.Msg {
 		return descEditDoneMsg{err: err, tempFile: tmpPath}
 	})
 }
@@ -467,15 +517,26 @@ func (m *Model) clearEditingModes() {
 	m.descEditing = false
 	m.tagsEditing = false
 	m.dueEditing = false
+	m.dueWaitUUID = ""
 	m.recurEditing = false
 	m.recurSeries = false
 	m.recurRoot = ""
 	m.projEditing = false
 	m.filterEditing = false
 	m.addingTask = false
+	m.addAnnotations = nil
+	m.enrichRejected = nil
+	m.templatePicking = false
+	m.addFormActive = false
+	m.subtaskAdding = false
+	m.importPathEditing = false
+	m.exportPathEditing = false
+	m.standupEditing = false
+	m.groupEditing = false
 	m.searching = false
 	m.shellActive = false
 	m.prioritySelecting = false
+	m.refreshIntervalEditing = false
 }
 
 // startDetailBlink starts blinking a field in the detail view
@@ -490,10 +551,11 @@ func (m *Model) startDetailBlink(fieldIndex int) tea.Cmd {
 	m.detailBlinkField = fieldIndex
 	m.detailBlinkOn = true
 	m.detailBlinkCount = blinkCycles
-	return blinkCmd()
+	return m.blinkTick()
 }
 
 func (m *Model) startBlink(id int, markDone bool) tea.Cmd {
+	m.clearBlinkTasks()
 	m.blinkID = id
 	m.blinkMarkDone = markDone
 
@@ -537,9 +599,48 @@ func (m *Model) startBlink(id int, markDone bool) tea.Cmd {
 	m.blinkOn = true
 	m.blinkCount = 0
 	m.updateBlinkRow()
+	return m.blinkTick()
+}
+
+// blinkUUIDs blinks the rows of the given tasks together. Unlike startBlink
+// it leaves key input alone, as the highlight only points out the tasks.
+func (m *Model) blinkUUIDs(uuids map[string]bool) tea.Cmd {
+	m.clearBlinkTasks()
+	if !m.blinkEnabled || len(uuids) == 0 {
+		return nil
+	}
+	m.blinkTasks = uuids
+	m.blinkOn = true
+	m.blinkCount = 0
+	m.updateBlinkRow()
+	return m.blinkTick()
+}
+
+// clearBlinkTasks stops blinking the rows of blinkUUIDs.
+func (m *Model) clearBlinkTasks() {
+	uuids := m.blinkTasks
+	m.blinkTasks = nil
+	m.renderTaskRows(uuids)
+}
+
+// blinkTick schedules the next row blink tick unless one is pending, so
+// that a blink started during another keeps a single tick loop.
+func (m *Model) blinkTick() tea.Cmd {
+	if m.blinkTicking {
+		return nil
+	}
+	m.blinkTicking = true
 	return blinkCmd()
 }
 
+// blinking reports whether the row of t is currently shown inverted.
+func (m *Model) blinking(t task.Task) bool {
+	if !m.blinkOn {
+		return false
+	}
+	return (m.blinkID != 0 && t.ID == m.blinkID) || (t.UUID != "" && m.blinkTasks[t.UUID])
+}
+
 // New creates a new UI model with the provided rows.
 func New(filters []string, browserCmd string) (Model, error) {
 	return NewWithTaskwarrior(filters, browserCmd, task.NewTaskwarrior())
@@ -576,6 +677,34 @@ func NewWithTaskwarrior(filters []string, browserCmd string, tw task.Taskwarrior
 	m.addInput.Prompt = "add: "
 	m.shellInput = textinput.New()
 	m.shellInput.Prompt = "task "
+	m.refreshIntervalInput = textinput.New()
+	m.refreshIntervalInput.Prompt = "auto-refresh: "
+	m.templateInput = textinput.New()
+	m.templateInput.Prompt = "template: "
+	m.templateInput.ShowSuggestions = true
+	m.addFormInputs = newAddFormInputs()
+	m.subtaskInput = textinput.New()
+	m.importPathInput = textinput.New()
+	m.importPathInput.Prompt = "import file: "
+	m.exportPathInput = textinput.New()
+	m.exportPathInput.Prompt = "export to: "
+	m.standupInput = textinput.New()
+	m.standupInput.Prompt = "standup since: "
+	m.groupInput = textinput.New()
+	m.groupInput.Prompt = "group by: "
+	m.groupInput.Placeholder = "project, tag, due, priority, uda:NAME or empty"
+	m.urgencyTuneInput = textinput.New()
+	m.seriesInput = textinput.New()
+	m.refactorInput = textinput.New()
+	m.heatmapInput = textinput.New()
+	m.heatmapInput.Prompt = "filter: "
+	m.heatmapInput.Placeholder = "project:work +home"
+	m.batchAddDefaults = textinput.New()
+	m.batchAddDefaults.Prompt = "modifiers: "
+	m.batchAddDefaults.Placeholder = "project:work +meeting"
+	m.batchAddList = textarea.New()
+	m.batchAddList.Placeholder = "- [ ] one task per line"
+	m.batchAddList.MaxHeight = 0 // a pasted list may be long
 
 	m.defaultTheme = DefaultTheme()
 	m.theme = m.defaultTheme
@@ -613,6 +742,10 @@ func (m *Model) reload() error {
 
 	m.processTasks(&data)
 	m.renderTasks(data)
+	if m.sidebarVisible {
+		// The counts are a convenience; a failed refresh keeps the old ones.
+		_ = m.loadSidebar()
+	}
 	return nil
 }
 
@@ -629,14 +762,18 @@ func (m *Model) fetchTasks() (reloadData, error) {
 	}
 
 	m.taskwarriorClient().SortTasks(tasks)
+	m.applyUrgencyPreviewToTasks(tasks)
+	tasks, depth := nestSubtasks(tasks)
 	return reloadData{
 		tasks:          tasks,
+		subtaskDepth:   depth,
 		ultraFilterIDs: m.ultraFilteredTaskIDs(),
 	}, nil
 }
 
 func (m *Model) processTasks(data *reloadData) {
 	m.tasks = data.tasks
+	m.subtaskDepth = data.subtaskDepth
 	m.total = m.taskwarriorClient().TotalTasks(data.tasks)
 	m.inProgress = m.taskwarriorClient().InProgressTasks(data.tasks)
 	m.due = m.taskwarriorClient().DueTasks(data.tasks, time.Now())
@@ -661,40 +798,42 @@ func (m *Model) renderTasks(data reloadData) {
 	} else {
 		m.tbl.SetRows(rows)
 	}
+	m.tbl.SetHeaderRows(m.groupHeaderRows())
 	m.reconcileUltraSelection()
 	m.updateSelectionHighlight(-1, m.tbl.Cursor(), 0, m.tbl.ColumnCursor())
 }
 
+// buildTaskRows renders tasks as table rows and collects the search
+// matches. When grouping, each group starts with a header row and the tasks
+// of collapsed groups are left out; rowTask maps the rows back to tasks.
 func (m *Model) buildTaskRows(tasks []task.Task) []atable.Row {
 	rows := make([]atable.Row, 0, len(tasks))
 	m.searchMatches = nil
-	for i, tsk := range tasks {
-		rows = append(rows, m.taskToRowSearch(tsk, m.searchRegex, m.tblStyles, -1))
-		if m.searchRegex == nil {
-			continue
+	m.rowTask, m.taskRow, m.rowGroup = nil, nil, nil
+	if m.groupBy == "" {
+		for i := range tasks {
+			rows = append(rows, m.taskRowWithMatches(tasks[i], i))
 		}
-		if m.searchRegex.MatchString(tsk.Project) {
-			if c := m.logicalToDisplay(colProject); c >= 0 {
-				m.searchMatches = append(m.searchMatches, cellMatch{row: i, col: c})
-			}
-		}
-		tags := strings.Join(tsk.Tags, " ")
-		if m.searchRegex.MatchString(tags) {
-			if c := m.logicalToDisplay(colTags); c >= 0 {
-				m.searchMatches = append(m.searchMatches, cellMatch{row: i, col: c})
-			}
+	} else {
+		m.taskRow = make([]int, len(tasks))
+		for i := range m.taskRow {
+			m.taskRow[i] = -1
 		}
-		if m.searchRegex.MatchString(tsk.Description) {
-			if c := m.logicalToDisplay(colDescription); c >= 0 {
-				m.searchMatches = append(m.searchMatches, cellMatch{row: i, col: c})
+		for _, g := range groupTasks(tasks, m.groupBy, time.Now()) {
+			collapsed := m.groupCollapsed[g.name]
+			rows = append(rows, atable.Row{groupHeader(g, tasks, collapsed)})
+			m.rowTask = append(m.rowTask, -1)
+			m.rowGroup = append(m.rowGroup, g.name)
+			if collapsed {
+				continue
 			}
-		}
-		for _, a := range tsk.Annotations {
-			if m.searchRegex.MatchString(a.Description) {
-				if c := m.logicalToDisplay(colAnnotations); c >= 0 {
-					m.searchMatches = append(m.searchMatches, cellMatch{row: i, col: c})
+			for _, i := range g.tasks {
+				if m.taskRow[i] < 0 {
+					m.taskRow[i] = len(rows)
 				}
-				break
+				rows = append(rows, m.taskRowWithMatches(tasks[i], len(rows)))
+				m.rowTask = append(m.rowTask, i)
+				m.rowGroup = append(m.rowGroup, g.name)
 			}
 		}
 	}
@@ -704,6 +843,40 @@ func (m *Model) buildTaskRows(tasks []task.Task) []atable.Row {
 	return rows
 }
 
+// taskRowWithMatches renders tsk as table row r and records its search
+// matches.
+func (m *Model) taskRowWithMatches(tsk task.Task, r int) atable.Row {
+	row := m.taskToRowSearch(tsk, m.searchRegex, m.tblStyles, -1)
+	if m.searchRegex == nil {
+		return row
+	}
+	if m.searchRegex.MatchString(tsk.Project) {
+		if c := m.logicalToDisplay(colProject); c >= 0 {
+			m.searchMatches = append(m.searchMatches, cellMatch{row: r, col: c})
+		}
+	}
+	tags := strings.Join(tsk.Tags, " ")
+	if m.searchRegex.MatchString(tags) {
+		if c := m.logicalToDisplay(colTags); c >= 0 {
+			m.searchMatches = append(m.searchMatches, cellMatch{row: r, col: c})
+		}
+	}
+	if m.searchRegex.MatchString(tsk.Description) {
+		if c := m.logicalToDisplay(colDescription); c >= 0 {
+			m.searchMatches = append(m.searchMatches, cellMatch{row: r, col: c})
+		}
+	}
+	for _, a := range tsk.Annotations {
+		if m.searchRegex.MatchString(a.Description) {
+			if c := m.logicalToDisplay(colAnnotations); c >= 0 {
+				m.searchMatches = append(m.searchMatches, cellMatch{row: r, col: c})
+			}
+			break
+		}
+	}
+	return row
+}
+
 func (m *Model) reloadAndReport() bool {
 	if err := m.reload(); err != nil {
 		m.showError(fmt.Errorf("reloading tasks: %w", err))
@@ -712,8 +885,15 @@ func (m *Model) reloadAndReport() bool {
 	return true
 }
 
-// Init implements tea.Model.
-func (m *Model) Init() tea.Cmd { return nil }
+// Init implements tea.Model. When auto-refresh was enabled before the
+// program started (see SetAutoRefresh) the first reload tick is scheduled
+// here so the loop runs without a keypress.
+func (m *Model) Init() tea.Cmd {
+	if m.autoRefresh {
+		return autoRefreshCmd(m.currentAutoRefreshInterval(), m.autoRefreshGen)
+	}
+	return nil
+}
 
 // Update handles key and window events.
 func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -737,6 +917,27 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, launchShellEditorCmd(msg.tempFile)
 	case shellEditDoneMsg:
 		return m.handleShellEditDone(msg)
+	case bulkEditLaunchMsg:
+		if msg.err != nil {
+			m.closeBulkEdit()
+			m.showError(fmt.Errorf("preparing editor: %w", msg.err))
+			return m, nil
+		}
+		return m, launchBulkEditorCmd(msg.tempFile)
+	case bulkEditDoneMsg:
+		return m.handleBulkEditDone(msg)
+	case batchAddEditLaunchMsg:
+		if msg.err != nil {
+			m.showError(fmt.Errorf("preparing editor: %w", msg.err))
+			return m, nil
+		}
+		return m, launchBatchAddEditorCmd(msg.tempFile)
+	case batchAddEditDoneMsg:
+		return m.handleBatchAddEditDone(msg)
+	case tea.PasteMsg:
+		if m.batchAddActive && !m.batchAddPreview {
+			return m.handleBatchAddPaste(msg)
+		}
 	case shellCompletionMsg:
 		return m.handleShellCompletion(msg)
 	case openURLDoneMsg:
@@ -758,6 +959,36 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.shellOutputVisible {
 			return m.handleShellOutputMode(msg)
 		}
+		if m.bulkPhase != bulkPhaseNone {
+			return m.handleBulkEditMode(msg)
+		}
+		if m.batchAddActive {
+			return m.handleBatchAddMode(msg)
+		}
+		if m.importPreview {
+			return m.handleImportPreviewMode(msg)
+		}
+		if m.urgencyTuneActive {
+			return m.handleUrgencyTuneMode(msg)
+		}
+		if m.seriesActive {
+			return m.handleSeriesMode(msg)
+		}
+		if m.heatmapActive {
+			return m.handleHeatmapMode(msg)
+		}
+		if m.boardActive {
+			return m.handleBoardMode(msg)
+		}
+		if m.refactorActive {
+			return m.handleRefactorMode(msg)
+		}
+		if m.rulesActive {
+			return m.handleRulesMode(msg)
+		}
+		if m.inboxActive {
+			return m.handleInboxMode(msg)
+		}
 
 		// Check if we're in detail view
 		if m.showTaskDetail {
@@ -793,6 +1024,12 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return model, cmd
 		}
 
+		if m.sidebarFocused {
+			if handled, model, cmd := m.handleSidebarKey(msg); handled {
+				return model, cmd
+			}
+		}
+
 		// Otherwise handle normal mode
 		return m.handleNormalMode(msg)
 	}
@@ -812,10 +1049,10 @@ func (m *Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 // handleWindowResize handles window resize events
 func (m *Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
-	m.tbl.SetWidth(msg.Width)
+	m.windowWidth = msg.Width
 	m.windowHeight = msg.Height
 	m.shellInput.SetWidth(msg.Width)
-	m.computeColumnWidths()
+	m.resizeTable()
 	m.updateTableHeight()
 	if m.showUltra {
 		if m.ultraSearchRegex != nil {
@@ -842,29 +1079,52 @@ func (m *Model) handleWindowResize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 		m.shellOutputViewport.SetWidth(msg.Width)
 		m.shellOutputViewport.SetHeight(height)
 	}
+	if m.bulkPhase != bulkPhaseNone {
+		height := msg.Height - 2
+		if height < 1 {
+			height = 1
+		}
+		m.bulkViewport.SetWidth(msg.Width)
+		m.bulkViewport.SetHeight(height)
+	}
 
 	return m, nil
 }
 
-// handleBlinkMsg handles the blinking animation timer
+// handleBlinkMsg advances the detail view and row blinks. Both run on the
+// same tick loop, which goes on while either of them does.
 func (m *Model) handleBlinkMsg() (tea.Model, tea.Cmd) {
-	// Handle detail view blinking
-	if m.showTaskDetail && m.detailBlinkField != -1 {
-		m.detailBlinkOn = !m.detailBlinkOn
-		m.detailBlinkCount++
-
-		if m.detailBlinkCount >= blinkCycles {
-			m.detailBlinkField = -1
-			m.detailBlinkOn = false
-			m.detailBlinkCount = 0
-		} else {
-			return m, blinkCmd()
-		}
-		return m, nil
+	m.blinkTicking = false
+	m.advanceDetailBlink()
+	m.advanceRowBlink()
+	if m.detailBlinking() || m.blinkID != 0 || m.blinkTasks != nil {
+		return m, m.blinkTick()
 	}
+	return m, nil
+}
 
-	if m.blinkID == 0 {
-		return m, nil
+func (m *Model) detailBlinking() bool {
+	return m.showTaskDetail && m.detailBlinkField != -1
+}
+
+func (m *Model) advanceDetailBlink() {
+	if !m.detailBlinking() {
+		return
+	}
+	m.detailBlinkOn = !m.detailBlinkOn
+	m.detailBlinkCount++
+	if m.detailBlinkCount >= blinkCycles {
+		m.detailBlinkField = -1
+		m.detailBlinkOn = false
+		m.detailBlinkCount = 0
+	}
+}
+
+// advanceRowBlink toggles the blinking rows. After the last cycle it marks
+// the task of startBlink done, if asked to, and reloads the tasks.
+func (m *Model) advanceRowBlink() {
+	if m.blinkID == 0 && m.blinkTasks == nil {
+		return
 	}
 
 	m.blinkOn = !m.blinkOn
@@ -878,6 +1138,10 @@ func (m *Model) handleBlinkMsg() (tea.Model, tea.Cmd) {
 		m.blinkOn = false
 		m.blinkCount = 0
 		m.blinkMarkDone = false
+		m.clearBlinkTasks()
+		if id == 0 {
+			return
+		}
 
 		if mark {
 			for _, tsk := range m.tasks {
@@ -894,10 +1158,7 @@ func (m *Model) handleBlinkMsg() (tea.Model, tea.Cmd) {
 			}
 		}
 		m.reloadAndReport()
-		return m, nil
 	}
-
-	return m, blinkCmd()
 }
 
 // anyInputActive reports whether the user is currently entering text or
@@ -909,7 +1170,11 @@ func (m *Model) anyInputActive() bool {
 		m.recurEditing || m.projEditing || m.filterEditing || m.addingTask ||
 		m.prioritySelecting || m.searching || m.shellActive ||
 		m.shellOutputVisible || m.detailSearching || m.ultraSearching ||
-		m.detailDescEditing || m.editID != 0
+		m.detailDescEditing || m.refreshIntervalEditing || m.templatePicking ||
+		m.addFormActive || m.subtaskAdding || m.bulkOriginal != nil ||
+		m.batchAddActive || m.importPathEditing || m.importPreview ||
+		m.exportPathEditing || m.standupEditing || m.groupEditing || m.urgencyTuneActive ||
+		m.seriesActive || m.heatmapActive || m.boardActive || m.refactorActive || m.rulesActive || m.inboxActive || m.editID != 0
 }
 
 // handleAutoRefresh reloads the task list on the periodic auto-refresh tick
@@ -917,19 +1182,23 @@ func (m *Model) anyInputActive() bool {
 // alive) while the user is editing so input is never disrupted. Stale ticks
 // from a previous loop incarnation (after an off/on toggle) are dropped via
 // the generation token so duplicate loops cannot accumulate. Toggling
-// auto-refresh off stops the loop by not rescheduling.
+// auto-refresh off stops the loop by not rescheduling. In adaptive mode the
+// next delay is derived from whether the reload changed the task list, and
+// any added or changed tasks are briefly highlighted (see diff.go).
 func (m *Model) handleAutoRefresh(msg autoRefreshMsg) (tea.Model, tea.Cmd) {
 	if !m.autoRefresh || msg.gen != m.autoRefreshGen {
 		return m, nil
 	}
-	interval := m.autoRefreshInterval
-	if interval <= 0 {
-		interval = autoRefreshDefaultInterval
-	}
+	var blink tea.Cmd
 	if !m.anyInputActive() {
-		m.reloadAndReport()
+		if diff, ok := m.reloadWithDiff(); ok {
+			m.adaptAutoRefreshInterval()
+			if !diff.empty() {
+				blink = tea.Batch(m.showStatusTimed(diff.summary()), m.blinkUUIDs(diff.touched()))
+			}
+		}
 	}
-	return m, autoRefreshCmd(interval, m.autoRefreshGen)
+	return m, tea.Batch(blink, autoRefreshCmd(m.currentAutoRefreshInterval(), m.autoRefreshGen))
 }
 
 // View renders the table UI.
@@ -943,6 +1212,26 @@ func (m *Model) View() tea.View {
 		content = m.renderDetailScreen()
 	case m.shellOutputVisible:
 		content = m.renderShellOutputScreen()
+	case m.bulkPhase != bulkPhaseNone:
+		content = m.renderBulkEditScreen()
+	case m.batchAddActive:
+		content = m.renderBatchAddScreen()
+	case m.importPreview:
+		content = m.renderImportScreen()
+	case m.urgencyTuneActive:
+		content = m.renderUrgencyTuneScreen()
+	case m.seriesActive:
+		content = m.renderSeriesScreen()
+	case m.heatmapActive:
+		content = m.renderHeatmapScreen()
+	case m.boardActive:
+		content = m.renderBoardScreen()
+	case m.refactorActive:
+		content = m.renderRefactorScreen()
+	case m.rulesActive:
+		content = m.renderRulesScreen()
+	case m.inboxActive:
+		content = m.renderInboxScreen()
 	case m.showUltra:
 		content = m.renderUltraScreen()
 	default:
@@ -971,17 +1260,33 @@ func (m *Model) appendInlineInputOverlay(view string) string {
 	case m.tagsEditing:
 		overlay = m.tagsInput.View()
 	case m.recurEditing:
-		overlay = m.recurInput.View()
+		overlay = m.recurView()
 	case m.projEditing:
 		overlay = m.projInput.View()
 	case m.filterEditing:
 		overlay = m.filterInput.View()
 	case m.addingTask:
-		overlay = m.addInput.View()
+		overlay = m.addView()
 	case m.searching:
 		overlay = m.searchInput.View()
 	case m.shellActive:
 		overlay = m.shellInput.View()
+	case m.refreshIntervalEditing:
+		overlay = m.refreshIntervalInput.View()
+	case m.templatePicking:
+		overlay = m.templateInput.View()
+	case m.addFormActive:
+		overlay = m.addFormView()
+	case m.subtaskAdding:
+		overlay = m.subtaskInput.View()
+	case m.importPathEditing:
+		overlay = m.importPathInput.View()
+	case m.exportPathEditing:
+		overlay = m.exportPathInput.View()
+	case m.standupEditing:
+		overlay = m.standupInput.View()
+	case m.groupEditing:
+		overlay = m.groupInput.View()
 	}
 
 	if overlay != "" {
@@ -1002,9 +1307,13 @@ func (m *Model) renderTableScreen() string {
 	// expandedCellView is only appended when the user has toggled the
 	// expanded-cell panel open; including it unconditionally caused a
 	// double-render whenever cellExpanded was true.
+	tbl := m.tbl.View()
+	if m.sidebarVisible {
+		tbl = lipgloss.JoinHorizontal(lipgloss.Top, m.renderSidebar(m.tbl.Height()), " ", tbl)
+	}
 	view := lipgloss.JoinVertical(lipgloss.Left,
 		m.topStatusLine(),
-		m.tbl.View(),
+		tbl,
 		m.statusLine(),
 	)
 	if m.cellExpanded {
@@ -1088,13 +1397,35 @@ func (m *Model) helpSections() []uihelp.Section {
 				{Key: "pgup/pgdn, b", Desc: "page up/down"},
 				{Key: "1", Desc: "jump to random task"},
 				{Key: "2", Desc: "jump to random task (no due date)"},
+				{Key: "ctrl+g", Desc: "group by project, tag, due, priority or UDA"},
+				{Key: ">", Desc: "collapse group of selected task"},
+				{Key: "<", Desc: "expand nearest collapsed group"},
+				{Key: "[", Desc: "toggle project tree sidebar"},
+				{Key: "]", Desc: "focus sidebar (tab, l or esc back to table)"},
 			},
 		},
 		{
 			Title: "Task Management",
 			Items: []uihelp.Item{
 				{Key: "Enter", Desc: "view task details"},
+				{Key: "h (details)", Desc: "show task change history"},
 				{Key: "+", Desc: "add new task"},
+				{Key: "M", Desc: "add new task from template"},
+				{Key: "F", Desc: "add new task with a form"},
+				{Key: "S", Desc: "add subtask of selected task"},
+				{Key: "V", Desc: "bulk edit listed tasks in $EDITOR"},
+				{Key: "L", Desc: "batch add tasks from a list"},
+				{Key: "I", Desc: "import todo.txt/CSV/Markdown file"},
+				{Key: "X", Desc: "export listed tasks (md/csv/json/html/ics)"},
+				{Key: "Y", Desc: "standup report since a date"},
+				{Key: "=", Desc: "explain urgency of selected task"},
+				{Key: "~", Desc: "tune urgency coefficients (preview)"},
+				{Key: "P", Desc: "manage recurring series"},
+				{Key: "m", Desc: "completion heatmap and weekly trend"},
+				{Key: "K", Desc: "kanban board"},
+				{Key: "ctrl+t", Desc: "rename, merge or remove projects and tags"},
+				{Key: "ctrl+w", Desc: "preview and apply rewrite rules"},
+				{Key: "ctrl+n", Desc: "process the inbox one task at a time"},
 				{Key: "e, E", Desc: "edit entire task"},
 				{Key: "d", Desc: "mark task done"},
 				{Key: "D", Desc: "delete task/recurring series"},
@@ -1139,6 +1470,7 @@ func (m *Model) helpSections() []uihelp.Section {
 				{Key: "B", Desc: "toggle blinking"},
 				{Key: "v", Desc: "toggle compact view"},
 				{Key: "Z", Desc: "toggle auto-refresh"},
+				{Key: "z", Desc: "set auto-refresh interval/adaptive mode"},
 			},
 		},
 		{
@@ -1160,7 +1492,7 @@ func (m *Model) statusLine() string {
 	return lipgloss.NewStyle().
 		Foreground(lipgloss.Color(m.theme.StatusFG)).
 		Background(lipgloss.Color(m.theme.StatusBG)).
-		Width(m.tbl.Width()).
+		Width(m.screenWidth()).
 		Render(status)
 }
 
@@ -1169,17 +1501,19 @@ func (m *Model) topStatusLine() string {
 	if len(m.filters) > 0 {
 		line += " | filter: " + strings.Join(m.filters, " ")
 	}
-	if m.autoRefresh {
-		interval := m.autoRefreshInterval
-		if interval <= 0 {
-			interval = autoRefreshDefaultInterval
-		}
-		line += fmt.Sprintf(" | auto-refresh: on (%s)", interval)
+	if indicator := m.autoRefreshIndicator(); indicator != "" {
+		line += " | " + indicator
+	}
+	if m.urgencyPreview != nil {
+		line += " | urgency preview"
+	}
+	if m.groupBy != "" {
+		line += " | grouped by " + m.groupBy
 	}
 	return lipgloss.NewStyle().
 		Foreground(lipgloss.Color(m.theme.StatusFG)).
 		Background(lipgloss.Color(m.theme.StatusBG)).
-		Width(m.tbl.Width()).
+		Width(m.screenWidth()).
 		Render(line)
 }
 
@@ -1225,6 +1559,9 @@ func (m *Model) formatUrgency(u string, width int) string {
 }
 
 func (m *Model) dueView(showLabel bool) string {
+	if showLabel && m.dueWaitUUID != "" {
+		return fmt.Sprintf("wait: %s", m.dueDate.Format("2006-01-02"))
+	}
 	if showLabel {
 		return fmt.Sprintf("due: %s", m.dueDate.Format("2006-01-02"))
 	}
@@ -1284,7 +1621,7 @@ func (m *Model) taskToRowSearch(t task.Task, re *regexp.Regexp, styles atable.St
 	if t.Start != "" {
 		rowStyle = rowStyle.Background(lipgloss.Color(m.theme.StartBG))
 	}
-	if t.ID == m.blinkID && m.blinkOn {
+	if m.blinking(t) {
 		rowStyle = rowStyle.Reverse(true)
 	}
 
@@ -1327,6 +1664,9 @@ func (m *Model) taskToRowSearch(t task.Task, re *regexp.Regexp, styles atable.St
 	}
 	annStr := m.highlightCellMatch(getStyle(colAnnotations), re, annRaw, annCount)
 	descStr := m.highlightCell(getStyle(colDescription), re, t.Description)
+	if prefix := m.subtaskPrefix(t); prefix != "" {
+		descStr = getStyle(colDescription).Render(prefix) + descStr
+	}
 	urgStr := getStyle(colUrgency).Render(m.formatUrgency(urg, m.urgWidth))
 
 	cells := map[int]string{
@@ -1351,9 +1691,9 @@ func (m *Model) taskToRowSearch(t task.Task, re *regexp.Regexp, styles atable.St
 }
 
 func (m *Model) expandedCellView() string {
-	row := m.tbl.Cursor()
+	row := m.taskIndexAtRow(m.tbl.Cursor())
 	col := m.tbl.ColumnCursor()
-	if row < 0 || row >= len(m.tasks) || col < 0 {
+	if row < 0 || col < 0 {
 		return ""
 	}
 	logical := m.displayToLogical(col)
@@ -1405,21 +1745,41 @@ func (m *Model) updateSelectionHighlight(prevRow, newRow, prevCol, newCol int) {
 		return
 	}
 	rows := m.tbl.Rows()
-	if prevRow >= 0 && prevRow < len(rows) {
-		rows[prevRow] = m.taskToRowSearch(m.tasks[prevRow], m.searchRegex, m.tblStyles, -1)
+	if i := m.taskIndexAtRow(prevRow); i >= 0 && prevRow < len(rows) {
+		rows[prevRow] = m.taskToRowSearch(m.tasks[i], m.searchRegex, m.tblStyles, -1)
 	}
-	if newRow >= 0 && newRow < len(rows) {
-		rows[newRow] = m.taskToRowSearch(m.tasks[newRow], m.searchRegex, m.tblStyles, newCol)
+	if i := m.taskIndexAtRow(newRow); i >= 0 && newRow < len(rows) {
+		rows[newRow] = m.taskToRowSearch(m.tasks[i], m.searchRegex, m.tblStyles, newCol)
 	}
 	m.tbl.SetRows(rows)
 }
 
+// updateBlinkRow re-renders the table rows of the blinking tasks.
 func (m *Model) updateBlinkRow() {
-	if m.blinkRow < 0 || m.blinkRow >= len(m.tasks) || m.tbl.Rows() == nil {
+	m.renderTaskRows(m.blinkTasks)
+	if m.blinkID == 0 || m.blinkRow < 0 || m.blinkRow >= len(m.tasks) || m.tbl.Rows() == nil {
 		return
 	}
+	row := m.rowOfTask(m.blinkRow)
 	rows := m.tbl.Rows()
-	rows[m.blinkRow] = m.taskToRowSearch(m.tasks[m.blinkRow], m.searchRegex, m.tblStyles, -1)
+	if row < 0 || row >= len(rows) {
+		return
+	}
+	rows[row] = m.taskToRowSearch(m.tasks[m.blinkRow], m.searchRegex, m.tblStyles, -1)
+	m.tbl.SetRows(rows)
+}
+
+// renderTaskRows re-renders the table rows of the tasks in uuids.
+func (m *Model) renderTaskRows(uuids map[string]bool) {
+	rows := m.tbl.Rows()
+	if rows == nil || len(uuids) == 0 {
+		return
+	}
+	for r := range rows {
+		if i := m.taskIndexAtRow(r); i >= 0 && uuids[m.tasks[i].UUID] {
+			rows[r] = m.taskToRowSearch(m.tasks[i], m.searchRegex, m.tblStyles, -1)
+		}
+	}
 	m.tbl.SetRows(rows)
 }
 
@@ -1433,9 +1793,15 @@ func (m *Model) updateTableHeight() {
 	if m.cellExpanded {
 		h--
 	}
-	if m.annotating || m.dueEditing || m.prioritySelecting || m.searching || m.descEditing || m.tagsEditing || m.recurEditing || m.projEditing || m.filterEditing || m.addingTask || m.shellActive {
+	if m.annotating || m.dueEditing || m.prioritySelecting || m.searching || m.descEditing || m.tagsEditing || m.recurEditing || m.projEditing || m.filterEditing || m.addingTask || m.shellActive || m.refreshIntervalEditing || m.templatePicking || m.subtaskAdding || m.importPathEditing || m.exportPathEditing || m.standupEditing || m.groupEditing {
 		h--
 	}
+	if m.addingTask && m.quickAdd {
+		h-- // quick add preview
+	}
+	if m.addFormActive {
+		h -= addFormFieldCount
+	}
 	if h < 1 {
 		h = 1
 	}
@@ -1589,6 +1955,7 @@ func (m *Model) applyColumns() {
 
 func (m *Model) applyTheme() {
 	m.tblStyles.Header = m.tblStyles.Header.Foreground(lipgloss.Color(m.theme.HeaderFG))
+	m.tblStyles.Group = m.tblStyles.Group.Foreground(lipgloss.Color(m.theme.HeaderFG))
 	m.tblStyles.Selected = m.tblStyles.Selected.Foreground(lipgloss.Color(m.theme.SelectedFG)).Background(lipgloss.Color(m.theme.SelectedBG))
 	m.tblStyles.Highlight = m.tblStyles.Highlight.Background(lipgloss.Color(m.theme.RowBG)).Foreground(lipgloss.Color(m.theme.RowFG))
 	m.tbl.SetStyles(m.tblStyles)
@@ -1710,19 +2077,34 @@ var reservedAgentHotkeys = map[string]struct{}{
 	"0":      {},
 	"1":      {},
 	"2":      {},
+	"<":      {},
+	"=":      {},
+	">":      {},
 	"A":      {},
 	"B":      {},
 	"C":      {},
 	"E":      {},
+	"F":      {},
 	"G":      {},
 	"H":      {},
+	"I":      {},
 	"J":      {},
+	"K":      {},
+	"L":      {},
+	"M":      {},
 	"N":      {},
+	"P":      {},
 	"R":      {},
 	"ctrl+r": {},
+	"S":      {},
 	"T":      {},
 	"U":      {},
+	"V":      {},
 	"W":      {},
+	"X":      {},
+	"Y":      {},
+	"[":      {},
+	"]":      {},
 	"a":      {},
 	"b":      {},
 	"c":      {},
@@ -1734,6 +2116,7 @@ var reservedAgentHotkeys = map[string]struct{}{
 	"esc":    {},
 	"f":      {},
 	"g":      {},
+	"ctrl+g": {},
 	"home":   {},
 	"i":      {},
 	"h":      {},
@@ -1741,7 +2124,9 @@ var reservedAgentHotkeys = map[string]struct{}{
 	"k":      {},
 	"l":      {},
 	"left":   {},
+	"m":      {},
 	"n":      {},
+	"ctrl+n": {},
 	"o":      {},
 	"p":      {},
 	"pgdn":   {},
@@ -1753,10 +2138,14 @@ var reservedAgentHotkeys = map[string]struct{}{
 	"s":      {},
 	"space":  {},
 	"t":      {},
+	"ctrl+t": {},
 	"u":      {},
 	"up":     {},
 	"w":      {},
+	"ctrl+w": {},
 	"x":      {},
+	"z":      {},
+	"~":      {},
 	"?":      {},
 	"/":      {},
 }
@@ -24,10 +24,18 @@ type fakeTaskwarrior struct {
 	tasks                  []task.Task
 	exportFilters          [][]string
 	addLines               []string
+	addArgs                [][]string
+	statusChanges          []string
+	modifications          []string
+	imported               []task.Task
+	history                []task.HistoryEntry
+	urgencyConfig          task.UrgencyConfig
+	configChanges          []string
 	recurrences            []fakeRecurrenceChange
 	seriesRecurrences      []fakeSeriesRecurrenceChange
 	setRecurrenceErr       error
 	setSeriesRecurrenceErr error
+	completionSources      *task.CompletionSources
 }
 
 var _ task.Taskwarrior = (*fakeTaskwarrior)(nil)
@@ -58,6 +66,27 @@ func (f *fakeTaskwarrior) AddLineContext(_ context.Context, line string) error {
 	return nil
 }
 
+func (f *fakeTaskwarrior) AddArgsContext(_ context.Context, args []string) error {
+	f.addArgs = append(f.addArgs, append([]string(nil), args...))
+	f.tasks = append(f.tasks, task.Task{
+		ID:          len(f.tasks) + 1,
+		UUID:        fmt.Sprintf("fake-%d", len(f.tasks)+1),
+		Description: args[len(args)-1],
+		Status:      "pending",
+	})
+	return nil
+}
+
+func (f *fakeTaskwarrior) AddDependencyContext(_ context.Context, id int, uuid string) error {
+	for i := range f.tasks {
+		if f.tasks[i].ID == id {
+			f.tasks[i].Depends = append(f.tasks[i].Depends, uuid)
+			return nil
+		}
+	}
+	return fmt.Errorf("no task %d", id)
+}
+
 func (f *fakeTaskwarrior) SortTasks(tasks []task.Task) {
 	task.SortTasks(tasks)
 }
@@ -85,13 +114,21 @@ func (f *fakeTaskwarrior) RunShellLine(context.Context, string) (task.RunResult,
 }
 
 func (f *fakeTaskwarrior) LoadCompletionSources(context.Context) task.CompletionSources {
-	f.unexpected("LoadCompletionSources")
-	return task.CompletionSources{}
+	if f.completionSources == nil {
+		f.unexpected("LoadCompletionSources")
+		return task.CompletionSources{}
+	}
+	return *f.completionSources
 }
 
-func (f *fakeTaskwarrior) AnnotateContext(context.Context, int, string) error {
-	f.unexpected("AnnotateContext")
-	return nil
+func (f *fakeTaskwarrior) AnnotateContext(_ context.Context, id int, text string) error {
+	for i := range f.tasks {
+		if f.tasks[i].ID == id {
+			f.tasks[i].Annotations = append(f.tasks[i].Annotations, task.Annotation{Description: text})
+			return nil
+		}
+	}
+	return fmt.Errorf("no task %d", id)
 }
 
 func (f *fakeTaskwarrior) ReplaceAnnotations(context.Context, int, string) error {
@@ -154,14 +191,67 @@ func (f *fakeTaskwarrior) DoneContext(context.Context, int) error {
 	return nil
 }
 
-func (f *fakeTaskwarrior) SetStatusUUIDContext(context.Context, string, string) error {
-	f.unexpected("SetStatusUUIDContext")
+func (f *fakeTaskwarrior) SetStatusUUIDContext(_ context.Context, uuid, status string) error {
+	f.statusChanges = append(f.statusChanges, uuid+" "+status)
+	for i := range f.tasks {
+		if f.tasks[i].UUID == uuid {
+			f.tasks[i].Status = status
+		}
+	}
+	if status != "pending" {
+		kept := f.tasks[:0]
+		for _, tsk := range f.tasks {
+			if tsk.Status == "pending" {
+				kept = append(kept, tsk)
+			}
+		}
+		f.tasks = kept
+	}
+	return nil
+}
+
+func (f *fakeTaskwarrior) ModifyUUIDContext(_ context.Context, uuid string, args []string) error {
+	f.modifications = append(f.modifications, uuid+" "+strings.Join(args, " "))
+	return nil
+}
+
+func (f *fakeTaskwarrior) ImportContext(_ context.Context, tasks []task.Task) error {
+	for _, tsk := range tasks {
+		f.imported = append(f.imported, tsk)
+		tsk.ID = len(f.tasks) + 1
+		tsk.UUID = fmt.Sprintf("fake-%d", len(f.tasks)+1)
+		tsk.Status = "pending"
+		f.tasks = append(f.tasks, tsk)
+	}
 	return nil
 }
 
-func (f *fakeTaskwarrior) RecurringSeries(context.Context, string) ([]task.Task, error) {
-	f.unexpected("RecurringSeries")
-	return nil, nil
+func (f *fakeTaskwarrior) History(context.Context, string) ([]task.HistoryEntry, error) {
+	return f.history, nil
+}
+
+func (f *fakeTaskwarrior) LoadUrgencyConfig(context.Context) (task.UrgencyConfig, error) {
+	return f.urgencyConfig, nil
+}
+
+func (f *fakeTaskwarrior) SetConfigContext(_ context.Context, name, value string) error {
+	f.configChanges = append(f.configChanges, name+"="+value)
+	return nil
+}
+
+func (f *fakeTaskwarrior) ModifySeriesContext(_ context.Context, rootUUID string, args []string) error {
+	f.modifications = append(f.modifications, "series "+rootUUID+" "+strings.Join(args, " "))
+	return nil
+}
+
+func (f *fakeTaskwarrior) RecurringSeries(_ context.Context, rootUUID string) ([]task.Task, error) {
+	var series []task.Task
+	for _, t := range f.tasks {
+		if t.UUID == rootUUID || t.Parent == rootUUID {
+			series = append(series, t)
+		}
+	}
+	return series, nil
 }
 
 func (f *fakeTaskwarrior) unexpected(method string) {
@@ -199,8 +289,9 @@ func TestNewWithTaskwarriorUsesFakeForAddTask(t *testing.T) {
 	if !reflect.DeepEqual(fake.addLines, []string{"new task +agent"}) {
 		t.Fatalf("add lines = %v", fake.addLines)
 	}
-	if len(fake.exportFilters) != 2 {
-		t.Fatalf("export calls after add = %d, want 2", len(fake.exportFilters))
+	// The new task is looked up with +LATEST, then the table reloads.
+	if len(fake.exportFilters) != 3 || !reflect.DeepEqual(fake.exportFilters[1], []string{"+LATEST"}) {
+		t.Fatalf("export calls after add = %v, want +LATEST and a reload", fake.exportFilters[1:])
 	}
 	if len(m.tasks) != 2 {
 		t.Fatalf("model tasks = %d, want 2", len(m.tasks))
@@ -1691,6 +1782,45 @@ func TestRecurrenceHotkey(t *testing.T) {
 	}
 }
 
+func TestRecurrencePreview(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "x", Description: "Rent", Status: "pending", Due: "20260131T120000Z", Until: "20260501T000000Z"},
+		{ID: 2, UUID: "y", Description: "No due", Status: "pending"},
+	}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.selectTaskByID(1)
+	m.handleSetRecurrence()
+
+	m.recurInput.SetValue("monthly")
+	due, _ := parseTaskDate("20260131T120000Z")
+	day := due.Local().Day()
+	if preview := m.recurPreview(); !strings.HasPrefix(preview, "next: ") || !strings.HasSuffix(preview, "until 2026-05-01 ends the series") {
+		t.Fatalf("unexpected preview %q", preview)
+	}
+	if day == 31 && !strings.Contains(m.recurPreview(), "2026-02-28, Sat 2026-03-28, Tue 2026-04-28") {
+		t.Fatalf("monthly should clamp to the month end: %q", m.recurPreview())
+	}
+	m.recurInput.SetValue("4weeks")
+	if !strings.Contains(m.recurPreview(), "2026-04-25, then until") {
+		t.Fatalf("expected 3 dates before until, got %q", m.recurPreview())
+	}
+	m.recurInput.SetValue("sometimes")
+	if !strings.Contains(m.recurView(), "(unknown period)") {
+		t.Fatalf("expected an unknown period note, got %q", m.recurView())
+	}
+
+	m.recurEditing = false
+	m.selectTaskByID(2)
+	m.handleSetRecurrence()
+	m.recurInput.SetValue("daily")
+	if !strings.Contains(m.recurPreview(), "needs a due date") {
+		t.Fatalf("expected a due date note, got %q", m.recurPreview())
+	}
+}
+
 func TestRecurringSeriesRecurrenceHotkey(t *testing.T) {
 	fake := &fakeTaskwarrior{
 		tasks: []task.Task{
@@ -2418,6 +2548,23 @@ func TestAgentFilterHotkeyRejectsShellPromptSharedKeys(t *testing.T) {
 	}
 }
 
+func TestAgentFilterHotkeyRejectsReservedKeys(t *testing.T) {
+	for _, key := range []string{"<", ">", "[", "]", "ctrl+g", "Ctrl+G", "z", "M", "F", "S", "V", "L", "I", "X", "Y", "=", "~", "P", "m", "K", "ctrl+t", "ctrl+w", "ctrl+n"} {
+		t.Run(key, func(t *testing.T) {
+			var m Model
+			if err := m.SetAgentFilterHotkey(key); err == nil {
+				t.Fatalf("expected collision for hotkey %q", key)
+			}
+			if !reservedAgentHotkeyContains(normalizeAgentFilterHotkey(key)) {
+				t.Fatalf("hotkey %q is missing from the reserved hotkeys", key)
+			}
+			if got := m.agentFilterHotkeyLabel(); got != "3" {
+				t.Fatalf("colliding hotkey changed label: got %q want %q", got, "3")
+			}
+		})
+	}
+}
+
 func TestAgentFilterHotkeyCollisionIsRejected(t *testing.T) {
 	tmp := t.TempDir()
 	taskPath := filepath.Join(tmp, "task")
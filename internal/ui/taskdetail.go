@@ -70,6 +70,9 @@ func (m *Model) renderTaskDetail() string {
 	if t == nil {
 		return "No task selected"
 	}
+	if m.detailHistoryVisible {
+		return m.renderTaskHistory()
+	}
 
 	titleStyle, labelStyle, valueStyle, descStyle := m.detailStyles()
 
@@ -213,7 +216,7 @@ func (m *Model) renderDetailRecurField(labelStyle lipgloss.Style, cf int) string
 	if m.recurEditing && m.recurID == t.ID {
 		orig := m.recurInput.Prompt
 		m.recurInput.Prompt = ""
-		v := m.recurInput.View()
+		v := m.recurView()
 		m.recurInput.Prompt = orig
 		return m.renderEditingField("Recurrence", v, labelStyle, cf)
 	}
@@ -305,6 +308,7 @@ func (m *Model) renderDetailFooter(lines []string) []string {
 		lines = append(lines, ist.Render("Use ↑/k and ↓/j to navigate fields"))
 		lines = append(lines, ist.Render("Press i or Enter to edit (Priority, Tags, Due, Recurrence, Description)"))
 		lines = append(lines, ist.Render("Press d to mark task done, D to delete, U to undo last done/delete"))
+		lines = append(lines, ist.Render("Press h to show the change history"))
 		if m.detailSearching {
 			lines = append(lines, ist.Render("Type to search, Enter to confirm"))
 		} else {
@@ -0,0 +1,169 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/config"
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// templateData is the value templates are executed against. Selected is the
+// task under the cursor when the template was picked (zero if none), so a
+// template can write e.g. "project:{{.Selected.Project}}".
+type templateData struct {
+	Selected task.Task
+}
+
+// templateFuncs returns the helper functions available in task templates.
+// Dates use the ISO format Taskwarrior accepts for due:, wait: and friends.
+func templateFuncs(now time.Time) template.FuncMap {
+	day := func(offset int) string { return now.AddDate(0, 0, offset).Format("2006-01-02") }
+	return template.FuncMap{
+		"today":    func() string { return day(0) },
+		"tomorrow": func() string { return day(1) },
+		"days":     day,
+	}
+}
+
+// renderTaskTemplate expands the placeholders in tmpl's line and
+// annotations.
+func renderTaskTemplate(tmpl config.Template, selected task.Task, now time.Time) (string, []string, error) {
+	data := templateData{Selected: selected}
+	funcs := templateFuncs(now)
+
+	expand := func(name, text string) (string, error) {
+		t, err := template.New(name).Funcs(funcs).Option("missingkey=error").Parse(text)
+		if err != nil {
+			return "", fmt.Errorf("template %q: %w", tmpl.Name, err)
+		}
+		var b strings.Builder
+		if err := t.Execute(&b, data); err != nil {
+			return "", fmt.Errorf("template %q: %w", tmpl.Name, err)
+		}
+		return b.String(), nil
+	}
+
+	line, err := expand("line", tmpl.Line)
+	if err != nil {
+		return "", nil, err
+	}
+	var annotations []string
+	for _, text := range tmpl.Annotations {
+		ann, err := expand("annotation", text)
+		if err != nil {
+			return "", nil, err
+		}
+		if ann = strings.TrimSpace(ann); ann != "" {
+			annotations = append(annotations, ann)
+		}
+	}
+	return line, annotations, nil
+}
+
+// SetTemplates configures the task templates offered by the "M" picker. An
+// empty list falls back to config.DefaultTemplates.
+func (m *Model) SetTemplates(templates []config.Template) {
+	m.templates = append([]config.Template(nil), templates...)
+}
+
+func (m *Model) taskTemplates() []config.Template {
+	if len(m.templates) == 0 {
+		return config.DefaultTemplates
+	}
+	return m.templates
+}
+
+// findTaskTemplate looks a template up by name. An unambiguous prefix is
+// accepted too, so "b" picks "bug" when no other template starts with "b".
+func (m *Model) findTaskTemplate(name string) (config.Template, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return config.Template{}, fmt.Errorf("template name cannot be empty")
+	}
+	var matches []config.Template
+	for _, tmpl := range m.taskTemplates() {
+		if tmpl.Name == name {
+			return tmpl, nil
+		}
+		if strings.HasPrefix(tmpl.Name, name) {
+			matches = append(matches, tmpl)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return config.Template{}, fmt.Errorf("unknown template %q", name)
+	case 1:
+		return matches[0], nil
+	default:
+		return config.Template{}, fmt.Errorf("template %q is ambiguous", name)
+	}
+}
+
+// handleTemplatePicker opens the template name prompt. Tab completes the
+// template names.
+func (m *Model) handleTemplatePicker() (tea.Model, tea.Cmd) {
+	templates := m.taskTemplates()
+	names := make([]string, 0, len(templates))
+	for _, tmpl := range templates {
+		names = append(names, tmpl.Name)
+	}
+
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	m.templatePicking = true
+	m.templateInput.SetValue("")
+	m.templateInput.SetSuggestions(names)
+	m.templateInput.Focus()
+	m.updateTableHeight()
+	m.statusMsg = "Templates: " + strings.Join(names, ", ")
+	return m, nil
+}
+
+// handleTemplatePickMode handles the template name prompt. On enter the
+// template is expanded and the add prompt opens pre-filled with its line;
+// its annotations are attached once the task has been created.
+func (m *Model) handleTemplatePickMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	onEnter := func(value string) error {
+		tmpl, err := m.findTaskTemplate(value)
+		if err != nil {
+			return err
+		}
+		selected, _ := m.selectedTask()
+		line, annotations, err := renderTaskTemplate(tmpl, selected, time.Now())
+		if err != nil {
+			return err
+		}
+
+		m.clearEditingModes()
+		m.addingTask = true
+		m.addAnnotations = annotations
+		m.addInput.SetValue(line)
+		m.addInput.CursorEnd()
+		m.addInput.Focus()
+		m.statusMsg = fmt.Sprintf("Template %q", tmpl.Name)
+		return nil
+	}
+
+	onExit := func() {
+		m.templatePicking = false
+	}
+
+	return m.handleTextInput(msg, &m.templateInput, onEnter, onExit)
+}
+
+// annotateNewTask adds the template annotations to a freshly created task.
+func (m *Model) annotateNewTask(id int, annotations []string) error {
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	for _, ann := range annotations {
+		if err := m.taskwarriorClient().AnnotateContext(ctx, id, ann); err != nil {
+			return fmt.Errorf("annotating new task: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/config"
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestRenderTaskTemplate(t *testing.T) {
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	tmpl := config.Template{
+		Name:        "followup",
+		Line:        "project:{{.Selected.Project}} due:{{tomorrow}} ",
+		Annotations: []string{"from {{.Selected.Description}} on {{today}}", "{{if .Selected.Due}}had due{{end}}"},
+	}
+
+	line, anns, err := renderTaskTemplate(tmpl, task.Task{Project: "work", Description: "standup"}, now)
+	if err != nil {
+		t.Fatalf("renderTaskTemplate: %v", err)
+	}
+	if line != "project:work due:2026-04-01 " {
+		t.Fatalf("unexpected line %q", line)
+	}
+	if len(anns) != 1 || anns[0] != "from standup on 2026-03-31" {
+		t.Fatalf("expected empty annotations to be dropped, got %q", anns)
+	}
+
+	if _, _, err := renderTaskTemplate(config.Template{Name: "bad", Line: "{{.Nope}}"}, task.Task{}, now); err == nil {
+		t.Fatalf("expected error for unknown field")
+	}
+}
+
+func TestFindTaskTemplate(t *testing.T) {
+	m := Model{}
+	m.SetTemplates([]config.Template{{Name: "bug"}, {Name: "meeting"}, {Name: "meetup"}})
+
+	if tmpl, err := m.findTaskTemplate("b"); err != nil || tmpl.Name != "bug" {
+		t.Fatalf("expected unique prefix to match bug, got %+v, %v", tmpl, err)
+	}
+	if _, err := m.findTaskTemplate("meet"); err == nil || !strings.Contains(err.Error(), "ambiguous") {
+		t.Fatalf("expected ambiguous error, got %v", err)
+	}
+	if _, err := m.findTaskTemplate("zzz"); err == nil {
+		t.Fatalf("expected unknown template error")
+	}
+
+	m.SetTemplates(nil)
+	if tmpl, err := m.findTaskTemplate("bug"); err != nil || tmpl.Name != "bug" {
+		t.Fatalf("expected default templates when none configured, got %+v, %v", tmpl, err)
+	}
+}
+
+// TestTemplateAddFlow picks a template, submits the pre-filled add prompt and
+// checks that the task is created with the template line and annotations.
+func TestTemplateAddFlow(t *testing.T) {
+	fake := &fakeTaskwarrior{tasks: []task.Task{{ID: 1, UUID: "a", Description: "existing", Project: "home", Status: "pending"}}}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.SetTemplates([]config.Template{{
+		Name:        "chore",
+		Line:        "project:{{.Selected.Project}} +chore ",
+		Annotations: []string{"checklist"},
+	}})
+
+	m.handleTemplatePicker()
+	if !m.templatePicking {
+		t.Fatalf("expected template prompt to open")
+	}
+	m.templateInput.SetValue("chore")
+	m.handleTemplatePickMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.templatePicking || !m.addingTask {
+		t.Fatalf("expected add prompt to replace the template prompt")
+	}
+	if got := m.addInput.Value(); got != "project:home +chore " {
+		t.Fatalf("unexpected pre-filled add line %q", got)
+	}
+
+	m.addInput.SetValue(m.addInput.Value() + "Water plants")
+	m.handleAddTaskMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if len(fake.addLines) != 1 || fake.addLines[0] != "project:home +chore Water plants" {
+		t.Fatalf("unexpected add lines %q", fake.addLines)
+	}
+	created := m.taskByID(2)
+	if created == nil || len(created.Annotations) != 1 || created.Annotations[0].Description != "checklist" {
+		t.Fatalf("expected template annotation on new task, got %+v", created)
+	}
+	if m.addAnnotations != nil {
+		t.Fatalf("expected pending annotations to be cleared")
+	}
+}
+
+func TestTemplateAnnotatesTaskOutsideFilter(t *testing.T) {
+	fake := &unlistedNewTasksFake{fakeTaskwarrior: &fakeTaskwarrior{tasks: []task.Task{
+		{ID: 1, UUID: "a", Description: "existing", Status: "pending"},
+	}}, listed: 1}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.handleAddTask()
+	m.addAnnotations = []string{"checklist"}
+	m.addInput.SetValue("project:elsewhere Water plants")
+	m.handleAddTaskMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+
+	if anns := fake.tasks[1].Annotations; len(anns) != 1 || anns[0].Description != "checklist" {
+		t.Fatalf("expected the unlisted task to be annotated, got %+v", anns)
+	}
+}
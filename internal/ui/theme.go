@@ -23,6 +23,7 @@ type Theme struct {
 	PrioHighBG     string
 	SearchFG       string
 	SearchBG       string
+	HeatmapShades  [4]string // heatmap cells, from fewest to most completions
 }
 
 // DefaultTheme returns the color theme used by Task Samurai.
@@ -43,6 +44,8 @@ func DefaultTheme() Theme {
 		PrioHighBG:     "160", // dark red — subtler than bright 9
 		SearchFG:       "16",
 		SearchBG:       "220", // amber — easier on eyes than pure yellow 226
+		// greens, dark to bright
+		HeatmapShades: [4]string{"22", "28", "34", "46"},
 	}
 }
 
@@ -59,6 +62,7 @@ func RandomTheme() Theme {
 		PrioMedBG:      randColor(),
 		PrioHighBG:     randColor(),
 		SearchBG:       randColor(),
+		HeatmapShades:  randShades(),
 	}
 	th.SelectedFG = contrastColor(th.SelectedBG)
 	th.RowFG = contrastColor(th.RowBG)
@@ -67,6 +71,21 @@ func RandomTheme() Theme {
 	return th
 }
 
+// randShades returns four increasingly bright shades of a random hue from
+// the xterm color cube.
+func randShades() [4]string {
+	var r, g, b int
+	for r+g+b == 0 {
+		r, g, b = rand.Intn(2), rand.Intn(2), rand.Intn(2)
+	}
+	var shades [4]string
+	for i := range shades {
+		level := i + 2
+		shades[i] = strconv.Itoa(16 + 36*r*level + 6*g*level + b*level)
+	}
+	return shades
+}
+
 func randColor() string {
 	return strconv.Itoa(rand.Intn(256))
 }
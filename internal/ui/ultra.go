@@ -97,6 +97,22 @@ func (m *Model) ultraHelpSections() []uihelp.Section {
 				{Key: "D", Desc: "delete task/recurring series"},
 				{Key: "U", Desc: "undo last done/delete"},
 				{Key: "+", Desc: "add new task"},
+				{Key: "M", Desc: "add new task from template"},
+				{Key: "F", Desc: "add new task with a form"},
+				{Key: "S", Desc: "add subtask of selected task"},
+				{Key: "V", Desc: "bulk edit listed tasks in $EDITOR"},
+				{Key: "L", Desc: "batch add tasks from a list"},
+				{Key: "I", Desc: "import todo.txt/CSV/Markdown file"},
+				{Key: "X", Desc: "export listed tasks (md/csv/json/html/ics)"},
+				{Key: "Y", Desc: "standup report since a date"},
+				{Key: "=", Desc: "explain urgency of selected task"},
+				{Key: "~", Desc: "tune urgency coefficients (preview)"},
+				{Key: "P", Desc: "manage recurring series"},
+				{Key: "m", Desc: "completion heatmap and weekly trend"},
+				{Key: "K", Desc: "kanban board"},
+				{Key: "ctrl+t", Desc: "rename, merge or remove projects and tags"},
+				{Key: "ctrl+w", Desc: "preview and apply rewrite rules"},
+				{Key: "ctrl+n", Desc: "process the inbox one task at a time"},
 			},
 		},
 		{
@@ -131,6 +147,8 @@ func (m *Model) ultraHelpSections() []uihelp.Section {
 				{Key: "x", Desc: "toggle disco mode"},
 				{Key: "B", Desc: "toggle blinking"},
 				{Key: "v", Desc: "toggle compact view"},
+				{Key: "Z", Desc: "toggle auto-refresh"},
+				{Key: "z", Desc: "set auto-refresh interval/adaptive mode"},
 			},
 		},
 		{
@@ -287,7 +305,7 @@ func (m *Model) selectTaskByID(id int) bool {
 
 	prevRow := m.tbl.Cursor()
 	prevCol := m.tbl.ColumnCursor()
-	m.tbl.SetCursor(row)
+	m.tbl.SetCursor(m.rowOfTask(row))
 
 	if m.showUltra {
 		if ultraRow := m.ultraTaskIndexByID(id); ultraRow >= 0 {
@@ -335,8 +353,8 @@ func (m *Model) syncUltraTableSelection() {
 
 	prevRow := m.tbl.Cursor()
 	prevCol := m.tbl.ColumnCursor()
-	m.tbl.SetCursor(row)
-	m.updateSelectionHighlight(prevRow, row, prevCol, m.tbl.ColumnCursor())
+	m.tbl.SetCursor(m.rowOfTask(row))
+	m.updateSelectionHighlight(prevRow, m.tbl.Cursor(), prevCol, m.tbl.ColumnCursor())
 }
 
 func (m *Model) ultraOverlay() (string, int) {
@@ -369,17 +387,31 @@ func (m *Model) ultraInputOverlay() string {
 	case m.tagsEditing:
 		return m.tagsInput.View()
 	case m.recurEditing:
-		return m.recurInput.View()
+		return m.recurView()
 	case m.projEditing:
 		return m.projInput.View()
 	case m.filterEditing:
 		return m.filterInput.View()
 	case m.addingTask:
-		return m.addInput.View()
+		return m.addView()
 	case m.searching:
 		return m.searchInput.View()
 	case m.shellActive:
 		return m.shellInput.View()
+	case m.refreshIntervalEditing:
+		return m.refreshIntervalInput.View()
+	case m.templatePicking:
+		return m.templateInput.View()
+	case m.addFormActive:
+		return m.addFormView()
+	case m.subtaskAdding:
+		return m.subtaskInput.View()
+	case m.importPathEditing:
+		return m.importPathInput.View()
+	case m.exportPathEditing:
+		return m.exportPathInput.View()
+	case m.standupEditing:
+		return m.standupInput.View()
 	default:
 		return ""
 	}
@@ -428,12 +460,11 @@ func (m *Model) ultraModeStatus(tasks []task.Task) string {
 	if len(m.filters) > 0 {
 		title += " | filter: " + strings.Join(m.filters, " ")
 	}
-	if m.autoRefresh {
-		interval := m.autoRefreshInterval
-		if interval <= 0 {
-			interval = autoRefreshDefaultInterval
-		}
-		title += fmt.Sprintf(" | auto-refresh: on (%s)", interval)
+	if indicator := m.autoRefreshIndicator(); indicator != "" {
+		title += " | " + indicator
+	}
+	if m.urgencyPreview != nil {
+		title += " | urgency preview"
 	}
 	return fmt.Sprintf("%s | search: %s | %d tasks", title, filter, len(tasks))
 }
@@ -620,7 +651,7 @@ func (m *Model) renderUltraCard(t task.Task, width int, selected bool, re *regex
 	if card == "" {
 		return ""
 	}
-	blink := m.blinkID != 0 && m.blinkOn && t.ID == m.blinkID
+	blink := m.blinking(t)
 	if blink {
 		lines := strings.SplitN(card, "\n", 2)
 		lines[0] = "! " + lines[0]
@@ -1070,7 +1101,7 @@ func (m *Model) handleUltraMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
 		// Sync the table cursor to the task we were on in ultra mode.
 		tasks := m.ultraTaskList()
 		if cursor >= 0 && cursor < len(tasks) {
-			m.tbl.SetCursor(cursor)
+			m.tbl.SetCursor(m.rowOfTask(cursor))
 		}
 		return m, nil
 	case "/":
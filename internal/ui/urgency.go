@@ -0,0 +1,59 @@
+package ui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// handleUrgencyBreakdown shows how the urgency of the selected task is
+// built from Taskwarrior's coefficients.
+func (m *Model) handleUrgencyBreakdown() (tea.Model, tea.Cmd) {
+	t, ok := m.selectedTask()
+	if !ok {
+		return m, nil
+	}
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	tw := m.taskwarriorClient()
+	cfg, err := tw.LoadUrgencyConfig(ctx)
+	if err != nil {
+		return m, m.showErrorTimed(fmt.Errorf("loading urgency coefficients: %w", err))
+	}
+	// Blocking and blocked depend on tasks outside the current filter.
+	pending, err := tw.Export(ctx, "( status:pending or status:waiting )")
+	if err != nil {
+		return m, m.showErrorTimed(fmt.Errorf("loading dependencies: %w", err))
+	}
+	blocking, blocked := task.DependencyState(append(pending, t))
+
+	terms := cfg.UrgencyTerms(t, blocking[t.UUID], blocked[t.UUID], time.Now())
+	m.showShellOutput(fmt.Sprintf("Urgency of task %d: %.2f", t.ID, t.Urgency), urgencyBreakdownText(terms, t.Urgency))
+	m.ultraClearFocusedID()
+	return m, nil
+}
+
+// urgencyBreakdownText renders the terms as a table that adds up to total.
+// Whatever the coefficients do not explain is listed as "other".
+func urgencyBreakdownText(terms []task.UrgencyTerm, total float64) string {
+	width := len("other")
+	for _, term := range terms {
+		width = max(width, len(term.Name))
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%-*s  %11s  %6s  %8s\n", width, "Term", "Coefficient", "Factor", "Value")
+	for _, term := range terms {
+		fmt.Fprintf(&b, "%-*s  %11.2f  %6.2f  %8.2f\n", width, term.Name, term.Coefficient, term.Factor, term.Value)
+	}
+	if other := total - task.SumUrgency(terms); math.Abs(other) >= 0.005 {
+		fmt.Fprintf(&b, "%-*s  %11s  %6s  %8.2f\n", width, "other", "", "", other)
+	}
+	fmt.Fprintf(&b, "%s\n", strings.Repeat("-", width+33))
+	fmt.Fprintf(&b, "%-*s  %11s  %6s  %8.2f\n", width, "total", "", "", total)
+	return b.String()
+}
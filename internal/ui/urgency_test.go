@@ -0,0 +1,41 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestUrgencyBreakdown(t *testing.T) {
+	fake := &fakeTaskwarrior{
+		tasks: []task.Task{
+			{ID: 1, UUID: "u1", Description: "Ship", Project: "dev", Tags: []string{"next"}, Status: "pending", Start: "20261018T080000Z", Urgency: 21.5},
+			{ID: 2, UUID: "u2", Description: "Later", Status: "pending", Depends: task.Dependencies{"u1"}},
+		},
+		urgencyConfig: task.UrgencyConfig{Coefficients: map[string]float64{
+			"project": 1, "active": 4, "blocking": 8, "tags": 1, "user.tag.next": 15, "user.tag.other": 3,
+		}},
+	}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	if !m.selectTaskByID(1) {
+		t.Fatalf("task 1 not listed")
+	}
+
+	m.handleUrgencyBreakdown()
+	if !m.shellOutputVisible || m.shellOutputTitle != "Urgency of task 1: 21.50" {
+		t.Fatalf("expected the breakdown panel, got %q", m.shellOutputTitle)
+	}
+	out := m.shellOutputText
+	for _, want := range []string{"blocking            8.00    1.00      8.00", "tag +next", "other", "-7.30", "total", "21.50"} {
+		if !strings.Contains(out, want) {
+			t.Fatalf("breakdown missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "tag +other") {
+		t.Fatalf("non-matching tag listed:\n%s", out)
+	}
+}
@@ -0,0 +1,339 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/textinput"
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+// urgencyTuneState holds the "~" urgency tuning screen and the preview it
+// applies to the table.
+type urgencyTuneState struct {
+	urgencyTuneActive bool
+	urgencyTuneCursor int
+	urgencyTuneKeys   []string        // coefficient keys listed on the screen
+	urgencyTuneInput  textinput.Model // value or new coefficient prompt
+	urgencyTunePrompt int             // urgencyPromptNone, urgencyPromptValue or urgencyPromptAdd
+	urgencyTuneTasks  []task.Task     // the listed tasks with Taskwarrior's own urgency
+	urgencyBase       task.UrgencyConfig
+	urgencyTuned      task.UrgencyConfig
+	// urgencyPreview, when set, holds the coefficients the urgency column
+	// currently shows instead of urgencyBase.
+	urgencyPreview *task.UrgencyConfig
+}
+
+const (
+	urgencyPromptNone = iota
+	urgencyPromptValue
+	urgencyPromptAdd
+)
+
+// urgencyTuneStep is how much +/- change the selected coefficient.
+const urgencyTuneStep = 0.5
+
+// urgencyTuneFixedKeys are always offered on the tuning screen.
+var urgencyTuneFixedKeys = []string{"due", "uda.priority.H", "uda.priority.M", "uda.priority.L", "age"}
+
+// urgencyTuneKeyList returns the fixed keys followed by the tag and project
+// coefficients of cfg.
+func urgencyTuneKeyList(cfg task.UrgencyConfig) []string {
+	keys := append([]string(nil), urgencyTuneFixedKeys...)
+	var user []string
+	for key := range cfg.Coefficients {
+		if strings.HasPrefix(key, "user.tag.") || strings.HasPrefix(key, "user.project.") {
+			user = append(user, key)
+		}
+	}
+	sort.Strings(user)
+	return append(keys, user...)
+}
+
+// urgencyKeyName returns the label of a coefficient key.
+func urgencyKeyName(key string) string {
+	if tag, ok := strings.CutPrefix(key, "user.tag."); ok {
+		return "tag +" + tag
+	}
+	if project, ok := strings.CutPrefix(key, "user.project."); ok {
+		return "project " + project
+	}
+	if value, ok := strings.CutPrefix(key, "uda.priority."); ok {
+		return "priority " + value
+	}
+	return key
+}
+
+// parseUrgencyKey turns "+tag" or "project:name" into a coefficient key.
+func parseUrgencyKey(s string) (string, error) {
+	s = strings.TrimSpace(s)
+	if tag, ok := strings.CutPrefix(s, "+"); ok && tag != "" && !strings.ContainsAny(tag, " .") {
+		return "user.tag." + tag, nil
+	}
+	if project, ok := strings.CutPrefix(s, "project:"); ok && project != "" && !strings.Contains(project, " ") {
+		return "user.project." + project, nil
+	}
+	return "", fmt.Errorf("enter +tag or project:name")
+}
+
+// handleUrgencyTune opens the tuning screen, continuing from the active
+// preview if there is one.
+func (m *Model) handleUrgencyTune() (tea.Model, tea.Cmd) {
+	m.clearEditingModes()
+	m.ultraClearFocusedID()
+	if m.urgencyPreview == nil {
+		ctx, cancel := m.taskOperationContext()
+		base, err := m.taskwarriorClient().LoadUrgencyConfig(ctx)
+		cancel()
+		if err != nil {
+			return m, m.showErrorTimed(fmt.Errorf("loading urgency coefficients: %w", err))
+		}
+		m.urgencyBase = base
+		m.urgencyTuned = base.Clone()
+	} else {
+		m.urgencyTuned = m.urgencyPreview.Clone()
+	}
+
+	m.urgencyTuneTasks = append([]task.Task(nil), m.tasks...)
+	if m.urgencyPreview != nil {
+		task.RetuneUrgency(m.urgencyTuneTasks, *m.urgencyPreview, m.urgencyBase, time.Now())
+	}
+	m.urgencyTuneKeys = urgencyTuneKeyList(m.urgencyTuned)
+	m.urgencyTuneCursor = 0
+	m.urgencyTunePrompt = urgencyPromptNone
+	m.urgencyTuneActive = true
+	return m, nil
+}
+
+func (m *Model) closeUrgencyTune() {
+	m.urgencyTuneActive = false
+	m.urgencyTunePrompt = urgencyPromptNone
+	m.urgencyTuneInput.Blur()
+	m.urgencyTuneTasks = nil
+}
+
+// handleUrgencyTuneMode handles keys on the tuning screen. Nothing is
+// written until w; p shows the tuned urgency in the table instead.
+func (m *Model) handleUrgencyTuneMode(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	if m.urgencyTunePrompt != urgencyPromptNone {
+		return m.handleUrgencyTunePrompt(msg)
+	}
+	key := m.urgencyTuneKeys[m.urgencyTuneCursor]
+	switch msg.String() {
+	case "esc", "q":
+		m.closeUrgencyTune()
+	case "down", "j":
+		m.urgencyTuneCursor = min(m.urgencyTuneCursor+1, len(m.urgencyTuneKeys)-1)
+	case "up", "k":
+		m.urgencyTuneCursor = max(m.urgencyTuneCursor-1, 0)
+	case "+", "right", "l":
+		m.urgencyTuned.Coefficients[key] += urgencyTuneStep
+	case "-", "left", "h":
+		m.urgencyTuned.Coefficients[key] -= urgencyTuneStep
+	case "enter", "e":
+		m.urgencyTunePrompt = urgencyPromptValue
+		m.urgencyTuneInput.Prompt = urgencyKeyName(key) + ": "
+		m.urgencyTuneInput.SetValue(strconv.FormatFloat(m.urgencyTuned.Coefficients[key], 'f', -1, 64))
+		m.urgencyTuneInput.CursorEnd()
+		m.urgencyTuneInput.Focus()
+	case "a":
+		m.urgencyTunePrompt = urgencyPromptAdd
+		m.urgencyTuneInput.Prompt = "coefficient for (+tag or project:name): "
+		m.urgencyTuneInput.SetValue("")
+		m.urgencyTuneInput.Focus()
+	case "r":
+		m.urgencyTuned = m.urgencyBase.Clone()
+		m.urgencyTuneKeys = urgencyTuneKeyList(m.urgencyTuned)
+		m.urgencyTuneCursor = min(m.urgencyTuneCursor, len(m.urgencyTuneKeys)-1)
+	case "p":
+		return m.applyUrgencyPreview()
+	case "x":
+		return m.discardUrgencyPreview()
+	case "w":
+		return m.saveUrgencyCoefficients()
+	}
+	return m, nil
+}
+
+func (m *Model) handleUrgencyTunePrompt(msg tea.KeyPressMsg) (tea.Model, tea.Cmd) {
+	onEnter := func(value string) error {
+		if m.urgencyTunePrompt == urgencyPromptValue {
+			n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil {
+				return fmt.Errorf("invalid coefficient %q", value)
+			}
+			m.urgencyTuned.Coefficients[m.urgencyTuneKeys[m.urgencyTuneCursor]] = n
+			return nil
+		}
+		key, err := parseUrgencyKey(value)
+		if err != nil {
+			return err
+		}
+		if _, ok := m.urgencyTuned.Coefficients[key]; !ok {
+			m.urgencyTuned.Coefficients[key] = 0
+		}
+		m.urgencyTuneKeys = urgencyTuneKeyList(m.urgencyTuned)
+		for i, k := range m.urgencyTuneKeys {
+			if k == key {
+				m.urgencyTuneCursor = i
+			}
+		}
+		return nil
+	}
+	onExit := func() {
+		m.urgencyTunePrompt = urgencyPromptNone
+	}
+	return m.handleTextInput(msg, &m.urgencyTuneInput, onEnter, onExit)
+}
+
+// changedUrgencyKeys returns the keys whose tuned coefficient differs from
+// Taskwarrior's, sorted.
+func (m *Model) changedUrgencyKeys() []string {
+	var keys []string
+	for key, value := range m.urgencyTuned.Coefficients {
+		if m.urgencyBase.Coefficients[key] != value {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// applyUrgencyPreview shows the tuned urgency in the table.
+func (m *Model) applyUrgencyPreview() (tea.Model, tea.Cmd) {
+	m.closeUrgencyTune()
+	if len(m.changedUrgencyKeys()) == 0 {
+		m.urgencyPreview = nil
+		m.reloadAndReport()
+		return m, m.showStatusTimed("Coefficients unchanged: using Taskwarrior's urgency")
+	}
+	tuned := m.urgencyTuned.Clone()
+	m.urgencyPreview = &tuned
+	if !m.reloadAndReport() {
+		return m, nil
+	}
+	return m, m.showStatusTimed("Urgency preview on: press ~ to tune, w there to save or x to discard")
+}
+
+func (m *Model) discardUrgencyPreview() (tea.Model, tea.Cmd) {
+	m.closeUrgencyTune()
+	m.urgencyPreview = nil
+	if !m.reloadAndReport() {
+		return m, nil
+	}
+	return m, m.showStatusTimed("Urgency preview discarded")
+}
+
+// saveUrgencyCoefficients writes the changed coefficients to .taskrc with
+// "task config" and goes back to Taskwarrior's own urgency, which now
+// matches the preview.
+func (m *Model) saveUrgencyCoefficients() (tea.Model, tea.Cmd) {
+	keys := m.changedUrgencyKeys()
+	if len(keys) == 0 {
+		return m, m.showStatusTimed("No coefficient changes to save")
+	}
+	ctx, cancel := m.taskOperationContext()
+	defer cancel()
+	for _, key := range keys {
+		value := strconv.FormatFloat(m.urgencyTuned.Coefficients[key], 'f', -1, 64)
+		if err := m.taskwarriorClient().SetConfigContext(ctx, "urgency."+key+".coefficient", value); err != nil {
+			return m, m.showErrorTimed(fmt.Errorf("saving urgency.%s.coefficient: %w", key, err))
+		}
+	}
+	m.closeUrgencyTune()
+	m.urgencyPreview = nil
+	if !m.reloadAndReport() {
+		return m, nil
+	}
+	return m, m.showStatusTimed(fmt.Sprintf("Saved %d urgency coefficients to .taskrc", len(keys)))
+}
+
+// applyUrgencyPreviewToTasks recomputes the urgency of freshly loaded tasks
+// with the preview coefficients, if a preview is active. The table keeps its
+// usual order.
+func (m *Model) applyUrgencyPreviewToTasks(tasks []task.Task) {
+	if m.urgencyPreview == nil {
+		return
+	}
+	task.RetuneUrgency(tasks, m.urgencyBase, *m.urgencyPreview, time.Now())
+}
+
+// urgencyRank is one row of the ranking preview.
+type urgencyRank struct {
+	task             task.Task
+	oldRank, newRank int
+	oldUrg, newUrg   float64
+}
+
+// urgencyRanking ranks tasks by their urgency under base and under tuned,
+// ordered by the tuned ranking.
+func urgencyRanking(tasks []task.Task, base, tuned task.UrgencyConfig, now time.Time) []urgencyRank {
+	tunedTasks := append([]task.Task(nil), tasks...)
+	task.RetuneUrgency(tunedTasks, base, tuned, now)
+
+	order := func(urg func(i int) float64) []int {
+		idx := make([]int, len(tasks))
+		for i := range idx {
+			idx[i] = i
+		}
+		sort.SliceStable(idx, func(a, b int) bool { return urg(idx[a]) > urg(idx[b]) })
+		return idx
+	}
+	oldOrder := order(func(i int) float64 { return tasks[i].Urgency })
+	newOrder := order(func(i int) float64 { return tunedTasks[i].Urgency })
+
+	oldRank := make([]int, len(tasks))
+	for rank, i := range oldOrder {
+		oldRank[i] = rank + 1
+	}
+	ranks := make([]urgencyRank, len(tasks))
+	for rank, i := range newOrder {
+		ranks[rank] = urgencyRank{
+			task:    tasks[i],
+			oldRank: oldRank[i],
+			newRank: rank + 1,
+			oldUrg:  tasks[i].Urgency,
+			newUrg:  tunedTasks[i].Urgency,
+		}
+	}
+	return ranks
+}
+
+func (m *Model) renderUrgencyTuneScreen() string {
+	var lines []string
+	for i, key := range m.urgencyTuneKeys {
+		cursor := "  "
+		if i == m.urgencyTuneCursor {
+			cursor = "> "
+		}
+		base, tuned := m.urgencyBase.Coefficients[key], m.urgencyTuned.Coefficients[key]
+		line := fmt.Sprintf("%s%-20s %7.2f", cursor, urgencyKeyName(key), base)
+		if tuned != base {
+			line += fmt.Sprintf(" → %7.2f", tuned)
+		}
+		lines = append(lines, line)
+	}
+	if m.urgencyTunePrompt != urgencyPromptNone {
+		m.urgencyTuneInput.SetWidth(m.fullScreenWidth())
+		lines = append(lines, m.urgencyTuneInput.View())
+	}
+
+	lines = append(lines, "", fmt.Sprintf("%4s %4s %5s %17s  %s", "New", "Old", "Move", "Urgency", "Description"))
+	for _, r := range urgencyRanking(m.urgencyTuneTasks, m.urgencyBase, m.urgencyTuned, time.Now()) {
+		move := "="
+		if d := r.oldRank - r.newRank; d > 0 {
+			move = fmt.Sprintf("↑%d", d)
+		} else if d < 0 {
+			move = fmt.Sprintf("↓%d", -d)
+		}
+		lines = append(lines, fmt.Sprintf("%4d %4d %5s %7.2f → %7.2f  %s",
+			r.newRank, r.oldRank, move, r.oldUrg, r.newUrg, r.task.Description))
+	}
+	return m.renderFullScreen("Urgency tuning: nothing is written to .taskrc until w", lines,
+		"j/k select | +/- adjust | Enter set | a add tag/project | r reset | p preview in table | x discard preview | w save | Esc close")
+}
@@ -0,0 +1,83 @@
+package ui
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+
+	"codeberg.org/snonux/tasksamurai/internal/task"
+)
+
+func TestUrgencyTuning(t *testing.T) {
+	fake := &fakeTaskwarrior{
+		tasks: []task.Task{
+			{ID: 1, UUID: "u1", Description: "Alpha", Status: "pending", Urgency: 5},
+			{ID: 2, UUID: "u2", Description: "Beta", Status: "pending", Tags: []string{"x"}, Urgency: 2},
+		},
+		urgencyConfig: task.UrgencyConfig{Coefficients: map[string]float64{"user.tag.x": 0}},
+	}
+	m, err := NewWithTaskwarrior(nil, "firefox", fake)
+	if err != nil {
+		t.Fatalf("NewWithTaskwarrior: %v", err)
+	}
+	m.windowHeight = 30
+	key := func(s string) {
+		t.Helper()
+		m.handleUrgencyTuneMode(tea.KeyPressMsg{Code: rune(s[0]), Text: s})
+	}
+
+	m.handleUrgencyTune()
+	if !m.urgencyTuneActive || m.urgencyTuneKeys[len(m.urgencyTuneKeys)-1] != "user.tag.x" {
+		t.Fatalf("expected the tuning screen listing the tag coefficient, got %q", m.urgencyTuneKeys)
+	}
+	m.urgencyTuneCursor = len(m.urgencyTuneKeys) - 1
+	key("e")
+	m.urgencyTuneInput.SetValue("10")
+	m.handleUrgencyTuneMode(tea.KeyPressMsg{Code: tea.KeyEnter})
+	if m.urgencyTuned.Coefficients["user.tag.x"] != 10 {
+		t.Fatalf("tuned = %v", m.urgencyTuned.Coefficients)
+	}
+	if screen := m.renderUrgencyTuneScreen(); !strings.Contains(screen, "   1    2    ↑1    2.00 →   12.00  Beta") {
+		t.Fatalf("ranking preview missing:\n%s", screen)
+	}
+	if m.tasks[0].Description != "Alpha" {
+		t.Fatalf("table reordered before the preview was applied")
+	}
+
+	key("p")
+	if m.urgencyTuneActive || m.urgencyPreview == nil {
+		t.Fatalf("expected the preview to be applied")
+	}
+	if m.tasks[0].Description != "Alpha" || math.Abs(m.tasks[1].Urgency-12) > 1e-9 {
+		t.Fatalf("expected the previewed urgency in the usual order: %+v", m.tasks)
+	}
+	if !strings.Contains(m.topStatusLine(), "urgency preview") {
+		t.Fatalf("missing preview indicator")
+	}
+
+	m.handleUrgencyTune()
+	if m.urgencyTuned.Coefficients["user.tag.x"] != 10 || m.urgencyTuneTasks[1].Urgency != 2 {
+		t.Fatalf("expected to continue from the preview with Taskwarrior's urgency")
+	}
+	key("w")
+	if want := []string{"urgency.user.tag.x.coefficient=10"}; !reflect.DeepEqual(fake.configChanges, want) {
+		t.Fatalf("config changes = %q, want %q", fake.configChanges, want)
+	}
+	if m.urgencyPreview != nil || m.tasks[0].Description != "Alpha" {
+		t.Fatalf("expected Taskwarrior's urgency after saving")
+	}
+}
+
+func TestParseUrgencyKey(t *testing.T) {
+	for in, want := range map[string]string{"+next": "user.tag.next", "project:work.web": "user.project.work.web"} {
+		if got, err := parseUrgencyKey(in); err != nil || got != want {
+			t.Errorf("parseUrgencyKey(%q) = %q, %v", in, got, err)
+		}
+	}
+	if _, err := parseUrgencyKey("next"); err == nil {
+		t.Errorf("expected an error without + or project:")
+	}
+}